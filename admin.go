@@ -0,0 +1,459 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TopicSpec carries the parameters used to create a single topic via
+// AdminClient.CreateTopics, translating into the fields of a
+// createTopicsRequestV2Topic.
+type TopicSpec struct {
+	// Topic is the name of the topic to create.
+	Topic string
+
+	// NumPartitions is the number of partitions to create for the topic.
+	// -1 (or leaving ReplicaAssignment set) defers partition count to the
+	// replica assignment instead.
+	NumPartitions int
+
+	// ReplicationFactor is the replication factor for the topic. -1 (or
+	// leaving ReplicaAssignment set) defers replication factor to the
+	// replica assignment instead.
+	ReplicationFactor int
+
+	// ReplicaAssignment explicitly assigns partitions to brokers, keyed by
+	// partition number. When set, NumPartitions and ReplicationFactor must
+	// be left unset (-1).
+	ReplicaAssignment map[int32][]int32
+
+	// Config holds topic-level configuration overrides, such as
+	// "retention.ms" or "cleanup.policy".
+	Config map[string]string
+}
+
+func (spec TopicSpec) toRequestTopic() createTopicsRequestV2Topic {
+	numPartitions := int32(spec.NumPartitions)
+	if numPartitions == 0 {
+		numPartitions = -1
+	}
+	replicationFactor := int16(spec.ReplicationFactor)
+	if replicationFactor == 0 {
+		replicationFactor = -1
+	}
+
+	var assignments []createTopicsRequestV2ReplicaAssignment
+	for partition, replicas := range spec.ReplicaAssignment {
+		assignments = append(assignments, createTopicsRequestV2ReplicaAssignment{
+			Partition: partition,
+			Replicas:  replicas,
+		})
+	}
+
+	var configs []createTopicsRequestV2ConfigEntry
+	for name, value := range spec.Config {
+		configs = append(configs, createTopicsRequestV2ConfigEntry{
+			ConfigName:  name,
+			ConfigValue: value,
+		})
+	}
+
+	return createTopicsRequestV2Topic{
+		Topic:              spec.Topic,
+		NumPartitions:      numPartitions,
+		ReplicationFactor:  replicationFactor,
+		ReplicaAssignments: assignments,
+		ConfigEntries:      configs,
+	}
+}
+
+// CreateTopicsOptions configures an AdminClient.CreateTopics call.
+type CreateTopicsOptions struct {
+	// ValidateOnly, if true, asks the broker to validate the request
+	// without actually creating the topics.
+	ValidateOnly bool
+}
+
+// TopicResult reports the outcome of a single topic operation submitted to
+// the broker, e.g. by CreateTopics or DeleteTopics.
+type TopicResult struct {
+	Topic string
+	Error error
+}
+
+// TopicError is the error type AdminClient methods return when one or more
+// topics in a batched request failed, so that callers can inspect the
+// per-topic errors instead of only learning that "something" failed, as
+// Conn.createTopics does.
+type TopicError []TopicResult
+
+func (e TopicError) Error() string {
+	return fmt.Sprintf("kafka: %d of %d topic operations failed", e.failedCount(), len(e))
+}
+
+func (e TopicError) failedCount() int {
+	n := 0
+	for _, r := range e {
+		if r.Error != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// topicResults builds one TopicResult per (topic, errorCode) pair and, if
+// any errorCode was non-zero, a TopicError alongside them. names and
+// errorCodes must be the same length, one entry per topic in the request.
+//
+// Conn.createTopics/deleteTopics/createPartitions return as soon as they've
+// fully decoded the broker's response, even though they also return early
+// with an error the moment they see the first non-zero topic error code —
+// so the response passed in here always covers every topic in the request,
+// not just the ones up to the first failure.
+func topicResults(names []string, errorCodes []int16) ([]TopicResult, error) {
+	results := make([]TopicResult, len(names))
+	var topicErr TopicError
+	for i, name := range names {
+		var rerr error
+		if errorCodes[i] != 0 {
+			rerr = Error(errorCodes[i])
+		}
+		results[i] = TopicResult{Topic: name, Error: rerr}
+		if rerr != nil {
+			topicErr = append(topicErr, results[i])
+		}
+	}
+	if len(topicErr) > 0 {
+		return results, topicErr
+	}
+	return results, nil
+}
+
+// AdminClientConfig configures an AdminClient.
+type AdminClientConfig struct {
+	// Addr is the address of any broker in the cluster; the client
+	// discovers the controller via a Metadata request before issuing
+	// admin RPCs.
+	Addr string
+
+	// Dialer is used to connect to brokers. Defaults to DefaultDialer.
+	Dialer *Dialer
+
+	// ControllerRetries is how many times to retry an admin RPC after the
+	// broker we sent it to responds NOT_CONTROLLER, re-discovering the
+	// controller each time. Defaults to 3.
+	ControllerRetries int
+}
+
+func (config *AdminClientConfig) validate() {
+	if config.Dialer == nil {
+		config.Dialer = DefaultDialer
+	}
+	if config.ControllerRetries <= 0 {
+		config.ControllerRetries = 3
+	}
+}
+
+// AdminClient is a high-level client for the administrative Kafka APIs
+// (topic/config management), analogous to confluent-kafka-go's AdminClient.
+// Unlike Conn, which exposes the wire-level createTopics RPC directly and
+// returns on the first topic error, AdminClient discovers and talks to the
+// controller broker automatically, retries on NOT_CONTROLLER, and reports
+// per-topic results via TopicError rather than failing the whole call.
+type AdminClient struct {
+	config AdminClientConfig
+}
+
+// NewAdminClient creates an AdminClient using the given configuration.
+func NewAdminClient(config AdminClientConfig) *AdminClient {
+	config.validate()
+	return &AdminClient{config: config}
+}
+
+// controllerConn dials the current controller broker, discovering it via a
+// Metadata request against c.config.Addr.
+func (c *AdminClient) controllerConn(ctx context.Context) (*Conn, error) {
+	conn, err := c.config.Dialer.DialContext(ctx, "tcp", c.config.Addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	controller, err := conn.Controller()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.config.Dialer.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", controller.Host, controller.Port))
+}
+
+// withController runs fn against a connection to the current controller,
+// retrying with a freshly discovered controller if fn reports that the
+// broker it talked to was not the controller.
+func (c *AdminClient) withController(ctx context.Context, fn func(*Conn) error) error {
+	var err error
+
+	for attempt := 0; attempt <= c.config.ControllerRetries; attempt++ {
+		var conn *Conn
+		if conn, err = c.controllerConn(ctx); err != nil {
+			return err
+		}
+
+		err = fn(conn)
+		conn.Close()
+
+		if err != NotController {
+			return err
+		}
+	}
+
+	return err
+}
+
+// timeoutMS converts ctx's deadline, if any, into the millisecond Timeout
+// field the Kafka admin APIs expect; honoring the context deadline rather
+// than requiring callers to separately pass a timeout, unlike Conn's admin
+// methods which take an explicit Timeout.
+func timeoutMS(ctx context.Context) int32 {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	if d := time.Until(deadline); d > 0 {
+		return milliseconds(d)
+	}
+	return 0
+}
+
+// CreateTopics creates the given topics, returning one TopicResult per spec
+// and, if any failed, a TopicError alongside them.
+func (c *AdminClient) CreateTopics(ctx context.Context, specs []TopicSpec, options CreateTopicsOptions) ([]TopicResult, error) {
+	topics := make([]createTopicsRequestV2Topic, len(specs))
+	for i, spec := range specs {
+		topics[i] = spec.toRequestTopic()
+	}
+
+	request := createTopicsRequestV2{
+		Topics:       topics,
+		Timeout:      timeoutMS(ctx),
+		ValidateOnly: options.ValidateOnly,
+	}
+
+	var response createTopicsResponseV2
+
+	err := c.withController(ctx, func(conn *Conn) error {
+		var err error
+		response, err = conn.createTopics(request)
+		return err
+	})
+	if err != nil && err != NotController {
+		if _, ok := err.(Error); !ok {
+			return nil, err
+		}
+	}
+
+	names := make([]string, len(response.TopicErrors))
+	codes := make([]int16, len(response.TopicErrors))
+	for i, tr := range response.TopicErrors {
+		names[i] = tr.Topic
+		codes[i] = tr.ErrorCode
+	}
+	return topicResults(names, codes)
+}
+
+// DeleteTopics deletes the named topics, returning one TopicResult per
+// topic and, if any failed, a TopicError alongside them.
+func (c *AdminClient) DeleteTopics(ctx context.Context, topics []string) ([]TopicResult, error) {
+	request := deleteTopicsRequestV1{
+		Topics:  topics,
+		Timeout: timeoutMS(ctx),
+	}
+
+	var response deleteTopicsResponseV1
+
+	err := c.withController(ctx, func(conn *Conn) error {
+		var err error
+		response, err = conn.deleteTopics(request)
+		return err
+	})
+	if err != nil && err != NotController {
+		if _, ok := err.(Error); !ok {
+			return nil, err
+		}
+	}
+
+	names := make([]string, len(response.TopicErrors))
+	codes := make([]int16, len(response.TopicErrors))
+	for i, tr := range response.TopicErrors {
+		names[i] = tr.Topic
+		codes[i] = tr.ErrorCode
+	}
+	return topicResults(names, codes)
+}
+
+// CreatePartitions increases the partition count of the named topic to
+// totalCount, returning an error if the broker rejected the request (e.g.
+// because totalCount is lower than the topic's current partition count).
+func (c *AdminClient) CreatePartitions(ctx context.Context, topic string, totalCount int) error {
+	request := createPartitionsRequestV0{
+		Topics: []createPartitionsRequestV0Topic{
+			{
+				Topic:      topic,
+				Count:      int32(totalCount),
+				Assignment: nil,
+			},
+		},
+		Timeout: timeoutMS(ctx),
+	}
+
+	var response createPartitionsResponseV0
+
+	err := c.withController(ctx, func(conn *Conn) error {
+		var err error
+		response, err = conn.createPartitions(request)
+		return err
+	})
+	if err != nil && err != NotController {
+		return err
+	}
+
+	for _, tr := range response.TopicErrors {
+		if tr.ErrorCode != 0 {
+			return Error(tr.ErrorCode)
+		}
+	}
+	return nil
+}
+
+// ConfigResource identifies the resource (topic or broker) a
+// DescribeConfigs/AlterConfigs call applies to.
+type ConfigResource struct {
+	// Type is the resource type: ResourceTypeTopic or ResourceTypeBroker.
+	Type int8
+
+	// Name is the topic name, or the broker ID as a string, depending on
+	// Type.
+	Name string
+
+	// ConfigNames restricts DescribeConfigs to these configuration keys. A
+	// nil slice returns every config for the resource; a non-nil empty
+	// slice returns none.
+	ConfigNames []string
+
+	// ConfigEntries is used by AlterConfigs to supply the new config
+	// values for the resource.
+	ConfigEntries map[string]string
+}
+
+// Resource types for ConfigResource.Type, see
+// http://kafka.apache.org/protocol.html#The_Messages_DescribeConfigs
+const (
+	ResourceTypeTopic  int8 = 2
+	ResourceTypeBroker int8 = 4
+)
+
+// ConfigResourceResult reports the outcome of a DescribeConfigs/AlterConfigs
+// call for a single resource.
+type ConfigResourceResult struct {
+	Type    int8
+	Name    string
+	Error   error
+	Configs map[string]string
+}
+
+// DescribeConfigs fetches configuration for the given resources.
+func (c *AdminClient) DescribeConfigs(ctx context.Context, resources []ConfigResource) ([]ConfigResourceResult, error) {
+	reqResources := make([]describeConfigsRequestV0Resource, len(resources))
+	for i, res := range resources {
+		reqResources[i] = describeConfigsRequestV0Resource{
+			ResourceType: res.Type,
+			ResourceName: res.Name,
+			ConfigNames:  res.ConfigNames,
+		}
+	}
+
+	request := describeConfigsRequestV0{Resources: reqResources}
+
+	var response describeConfigsResponseV0
+
+	err := c.withController(ctx, func(conn *Conn) error {
+		var err error
+		response, err = conn.describeConfigs(request)
+		return err
+	})
+	if err != nil && err != NotController {
+		return nil, err
+	}
+
+	results := make([]ConfigResourceResult, len(response.Resources))
+	for i, res := range response.Resources {
+		var rerr error
+		if res.ErrorCode != 0 {
+			rerr = Error(res.ErrorCode)
+		}
+		configs := make(map[string]string, len(res.ConfigEntries))
+		for _, entry := range res.ConfigEntries {
+			configs[entry.ConfigName] = entry.ConfigValue
+		}
+		results[i] = ConfigResourceResult{
+			Type:    res.ResourceType,
+			Name:    res.ResourceName,
+			Error:   rerr,
+			Configs: configs,
+		}
+	}
+
+	return results, nil
+}
+
+// AlterConfigs overwrites the given resources' configuration with
+// ConfigResource.ConfigEntries.
+func (c *AdminClient) AlterConfigs(ctx context.Context, resources []ConfigResource) ([]ConfigResourceResult, error) {
+	reqResources := make([]alterConfigsRequestV0Resource, len(resources))
+	for i, res := range resources {
+		entries := make([]alterConfigsRequestV0ConfigEntry, 0, len(res.ConfigEntries))
+		for name, value := range res.ConfigEntries {
+			entries = append(entries, alterConfigsRequestV0ConfigEntry{
+				ConfigName:  name,
+				ConfigValue: value,
+			})
+		}
+		reqResources[i] = alterConfigsRequestV0Resource{
+			ResourceType:  res.Type,
+			ResourceName:  res.Name,
+			ConfigEntries: entries,
+		}
+	}
+
+	request := alterConfigsRequestV0{
+		Resources:    reqResources,
+		ValidateOnly: false,
+	}
+
+	var response alterConfigsResponseV0
+
+	err := c.withController(ctx, func(conn *Conn) error {
+		var err error
+		response, err = conn.alterConfigs(request)
+		return err
+	})
+	if err != nil && err != NotController {
+		return nil, err
+	}
+
+	results := make([]ConfigResourceResult, len(response.Resources))
+	for i, res := range response.Resources {
+		var rerr error
+		if res.ErrorCode != 0 {
+			rerr = Error(res.ErrorCode)
+		}
+		results[i] = ConfigResourceResult{
+			Type:  res.ResourceType,
+			Name:  res.ResourceName,
+			Error: rerr,
+		}
+	}
+
+	return results, nil
+}
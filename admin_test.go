@@ -0,0 +1,113 @@
+package kafka
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestTopicSpecToRequestTopicDefaults(t *testing.T) {
+	spec := TopicSpec{
+		Topic:             "my-topic",
+		NumPartitions:     3,
+		ReplicationFactor: 2,
+	}
+
+	got := spec.toRequestTopic()
+
+	want := createTopicsRequestV2Topic{
+		Topic:             "my-topic",
+		NumPartitions:     3,
+		ReplicationFactor: 2,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestTopicSpecToRequestTopicUnsetMeansMinusOne(t *testing.T) {
+	spec := TopicSpec{Topic: "my-topic"}
+
+	got := spec.toRequestTopic()
+
+	if got.NumPartitions != -1 {
+		t.Errorf("expected NumPartitions to default to -1, got %d", got.NumPartitions)
+	}
+	if got.ReplicationFactor != -1 {
+		t.Errorf("expected ReplicationFactor to default to -1, got %d", got.ReplicationFactor)
+	}
+}
+
+func TestTopicSpecToRequestTopicReplicaAssignment(t *testing.T) {
+	spec := TopicSpec{
+		Topic: "my-topic",
+		ReplicaAssignment: map[int32][]int32{
+			0: {1, 2, 3},
+			1: {2, 3, 1},
+		},
+	}
+
+	got := spec.toRequestTopic()
+
+	if len(got.ReplicaAssignments) != 2 {
+		t.Fatalf("expected one ReplicaAssignment entry per partition, got %d", len(got.ReplicaAssignments))
+	}
+
+	sort.Slice(got.ReplicaAssignments, func(i, j int) bool {
+		return got.ReplicaAssignments[i].Partition < got.ReplicaAssignments[j].Partition
+	})
+
+	want := []createTopicsRequestV2ReplicaAssignment{
+		{Partition: 0, Replicas: []int32{1, 2, 3}},
+		{Partition: 1, Replicas: []int32{2, 3, 1}},
+	}
+	if !reflect.DeepEqual(got.ReplicaAssignments, want) {
+		t.Fatalf("got %+v, want %+v", got.ReplicaAssignments, want)
+	}
+}
+
+func TestTopicResultsKeepsEveryTopicOnPartialFailure(t *testing.T) {
+	// The broker response a Conn admin method decodes covers every topic
+	// in the request, even though that method also returns an error as
+	// soon as it sees the first non-zero error code; topicResults must
+	// report on all of them, not just the ones up to the first failure.
+	names := []string{"topic-a", "topic-b", "topic-c", "topic-d", "topic-e"}
+	codes := []int16{0, 0, int16(TopicAlreadyExists), 0, 0}
+
+	results, err := topicResults(names, codes)
+
+	if len(results) != len(names) {
+		t.Fatalf("expected %d results, got %d", len(names), len(results))
+	}
+	for i, name := range names {
+		if results[i].Topic != name {
+			t.Errorf("result %d: expected topic %q, got %q", i, name, results[i].Topic)
+		}
+		wantErr := codes[i] != 0
+		if (results[i].Error != nil) != wantErr {
+			t.Errorf("result %d (%q): expected error presence %v, got %v", i, name, wantErr, results[i].Error)
+		}
+	}
+
+	topicErr, ok := err.(TopicError)
+	if !ok {
+		t.Fatalf("expected a TopicError, got %T", err)
+	}
+	if len(topicErr) != 1 || topicErr[0].Topic != "topic-c" {
+		t.Fatalf("expected exactly one failed topic (topic-c), got %+v", topicErr)
+	}
+}
+
+func TestTopicResultsNoErrorWhenAllSucceed(t *testing.T) {
+	names := []string{"topic-a", "topic-b"}
+	codes := []int16{0, 0}
+
+	results, err := topicResults(names, codes)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+}
@@ -0,0 +1,134 @@
+package kafka
+
+import (
+	"bufio"
+	"context"
+	"time"
+)
+
+type alterConfigsRequestV0ConfigEntry struct {
+	ConfigName  string
+	ConfigValue string
+}
+
+func (t alterConfigsRequestV0ConfigEntry) size() int32 {
+	return sizeofString(t.ConfigName) +
+		sizeofString(t.ConfigValue)
+}
+
+func (t alterConfigsRequestV0ConfigEntry) writeTo(w *bufio.Writer) {
+	writeString(w, t.ConfigName)
+	writeString(w, t.ConfigValue)
+}
+
+type alterConfigsRequestV0Resource struct {
+	// ResourceType is the type of resource being altered, e.g.
+	// ResourceTypeTopic or ResourceTypeBroker.
+	ResourceType int8
+
+	// ResourceName is the topic name, or the broker ID as a string.
+	ResourceName string
+
+	// ConfigEntries holds the new configuration to apply to the resource.
+	ConfigEntries []alterConfigsRequestV0ConfigEntry
+}
+
+func (t alterConfigsRequestV0Resource) size() int32 {
+	return sizeofInt8(t.ResourceType) +
+		sizeofString(t.ResourceName) +
+		sizeofArray(len(t.ConfigEntries), func(i int) int32 { return t.ConfigEntries[i].size() })
+}
+
+func (t alterConfigsRequestV0Resource) writeTo(w *bufio.Writer) {
+	writeInt8(w, t.ResourceType)
+	writeString(w, t.ResourceName)
+	writeArray(w, len(t.ConfigEntries), func(i int) { t.ConfigEntries[i].writeTo(w) })
+}
+
+// See http://kafka.apache.org/protocol.html#The_Messages_AlterConfigs
+type alterConfigsRequestV0 struct {
+	Resources []alterConfigsRequestV0Resource
+
+	// ValidateOnly if true, the request is validated but the configuration
+	// change isn't applied.
+	ValidateOnly bool
+}
+
+func (t alterConfigsRequestV0) size() int32 {
+	return sizeofArray(len(t.Resources), func(i int) int32 { return t.Resources[i].size() }) +
+		sizeofBool(t.ValidateOnly)
+}
+
+func (t alterConfigsRequestV0) writeTo(w *bufio.Writer) {
+	writeArray(w, len(t.Resources), func(i int) { t.Resources[i].writeTo(w) })
+	writeBool(w, t.ValidateOnly)
+}
+
+type alterConfigsResponseV0Resource struct {
+	ErrorCode    int16
+	ErrorMessage string
+	ResourceType int8
+	ResourceName string
+}
+
+func (t *alterConfigsResponseV0Resource) readFrom(r *bufio.Reader, size int) (remain int, err error) {
+	if remain, err = readInt16(r, size, &t.ErrorCode); err != nil {
+		return
+	}
+	if remain, err = readString(r, remain, &t.ErrorMessage); err != nil {
+		return
+	}
+	if remain, err = readInt8(r, remain, &t.ResourceType); err != nil {
+		return
+	}
+	if remain, err = readString(r, remain, &t.ResourceName); err != nil {
+		return
+	}
+	return
+}
+
+// See http://kafka.apache.org/protocol.html#The_Messages_AlterConfigs
+type alterConfigsResponseV0 struct {
+	ThrottleTimeMS int32
+	Resources      []alterConfigsResponseV0Resource
+}
+
+func (t *alterConfigsResponseV0) readFrom(r *bufio.Reader, size int) (remain int, err error) {
+	if remain, err = readInt32(r, size, &t.ThrottleTimeMS); err != nil {
+		return
+	}
+	remain, err = readArrayWith(r, remain, func(r *bufio.Reader, size int) (remain int, err error) {
+		var resource alterConfigsResponseV0Resource
+		remain, err = (&resource).readFrom(r, size)
+		t.Resources = append(t.Resources, resource)
+		return
+	})
+	return
+}
+
+func (c *Conn) alterConfigs(request alterConfigsRequestV0) (alterConfigsResponseV0, error) {
+	var response alterConfigsResponseV0
+
+	_, span := startRequestSpan(context.Background(), c.tracer(), "kafka.alter_configs", c.RemoteAddr().String(), alterConfigsRequest, v0, 0)
+	defer func() { finishRequestSpan(span, "", -1, nil) }()
+
+	err := c.readOperation(
+		func(deadline time.Time, id int32) error {
+			w := &c.wbuf
+			writeHeader(w, c.clientID, alterConfigsRequest, v0, id, request.size())
+			request.writeTo(w)
+			return w.Flush()
+		},
+		func(deadline time.Time, size int) error {
+			return expectZeroSize(func() (remain int, err error) {
+				return (&response).readFrom(&c.rbuf, size)
+			}())
+		},
+	)
+	if err != nil {
+		span.SetError(err)
+		return response, err
+	}
+
+	return response, nil
+}
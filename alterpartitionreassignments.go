@@ -0,0 +1,324 @@
+package kafka
+
+import (
+	"bufio"
+	"context"
+	"time"
+)
+
+// See https://cwiki.apache.org/confluence/display/KAFKA/KIP-455%3A+Create+an+Administrative+API+for+Replica+Reassignment
+
+type alterPartitionReassignmentsRequestV0ReplicaAssignment struct {
+	// Partition is the partition to reassign, or cancel the reassignment of.
+	Partition int32
+
+	// Replicas lists the brokers the partition should be assigned to. A nil
+	// slice (encoded as a null compact array) cancels any pending
+	// reassignment for the partition.
+	Replicas []int32
+}
+
+func (t alterPartitionReassignmentsRequestV0ReplicaAssignment) size() int32 {
+	return sizeofInt32(t.Partition) +
+		sizeofCompactInt32Array(t.Replicas) +
+		sizeofTaggedFields()
+}
+
+func (t alterPartitionReassignmentsRequestV0ReplicaAssignment) writeTo(w *bufio.Writer) {
+	writeInt32(w, t.Partition)
+	writeCompactInt32Array(w, t.Replicas)
+	writeTaggedFields(w)
+}
+
+type alterPartitionReassignmentsRequestV0Topic struct {
+	// Name is the topic name.
+	Name string
+
+	// Partitions lists the per-partition reassignments requested for this
+	// topic.
+	Partitions []alterPartitionReassignmentsRequestV0ReplicaAssignment
+}
+
+func (t alterPartitionReassignmentsRequestV0Topic) size() int32 {
+	return sizeofCompactString(t.Name) +
+		sizeofCompactArray(len(t.Partitions), func(i int) int32 { return t.Partitions[i].size() }) +
+		sizeofTaggedFields()
+}
+
+func (t alterPartitionReassignmentsRequestV0Topic) writeTo(w *bufio.Writer) {
+	writeCompactString(w, t.Name)
+	writeCompactArray(w, len(t.Partitions), func(i int) { t.Partitions[i].writeTo(w) })
+	writeTaggedFields(w)
+}
+
+// alterPartitionReassignmentsRequestV0 is a flexible (KIP-482) request, see
+// http://kafka.apache.org/protocol.html#The_Messages_AlterPartitionReassignments
+type alterPartitionReassignmentsRequestV0 struct {
+	// TimeoutMS is how long to wait, in milliseconds, for the request to
+	// complete on the controller before timing out.
+	TimeoutMS int32
+
+	// Topics holds the per-topic reassignments to submit.
+	Topics []alterPartitionReassignmentsRequestV0Topic
+}
+
+func (t alterPartitionReassignmentsRequestV0) size() int32 {
+	return sizeofInt32(t.TimeoutMS) +
+		sizeofCompactArray(len(t.Topics), func(i int) int32 { return t.Topics[i].size() }) +
+		sizeofTaggedFields()
+}
+
+func (t alterPartitionReassignmentsRequestV0) writeTo(w *bufio.Writer) {
+	writeInt32(w, t.TimeoutMS)
+	writeCompactArray(w, len(t.Topics), func(i int) { t.Topics[i].writeTo(w) })
+	writeTaggedFields(w)
+}
+
+type alterPartitionReassignmentsResponseV0PartitionError struct {
+	// Partition is the partition this error applies to.
+	Partition int32
+
+	// ErrorCode holds the response error code for this partition, or 0 if
+	// the reassignment was accepted.
+	ErrorCode int16
+
+	// ErrorMessage holds a human readable error message, or the empty
+	// string.
+	ErrorMessage string
+}
+
+func (t alterPartitionReassignmentsResponseV0PartitionError) size() int32 {
+	return sizeofInt32(t.Partition) +
+		sizeofInt16(t.ErrorCode) +
+		sizeofCompactString(t.ErrorMessage) +
+		sizeofTaggedFields()
+}
+
+func (t alterPartitionReassignmentsResponseV0PartitionError) writeTo(w *bufio.Writer) {
+	writeInt32(w, t.Partition)
+	writeInt16(w, t.ErrorCode)
+	writeCompactString(w, t.ErrorMessage)
+	writeTaggedFields(w)
+}
+
+func (t *alterPartitionReassignmentsResponseV0PartitionError) readFrom(r *bufio.Reader, size int) (remain int, err error) {
+	if remain, err = readInt32(r, size, &t.Partition); err != nil {
+		return
+	}
+	if remain, err = readInt16(r, remain, &t.ErrorCode); err != nil {
+		return
+	}
+	if remain, err = readCompactString(r, remain, &t.ErrorMessage); err != nil {
+		return
+	}
+	if remain, err = readTaggedFields(r, remain); err != nil {
+		return
+	}
+	return
+}
+
+type alterPartitionReassignmentsResponseV0TopicError struct {
+	// Name is the topic name this error applies to.
+	Name string
+
+	// PartitionErrors holds the per-partition results for this topic.
+	PartitionErrors []alterPartitionReassignmentsResponseV0PartitionError
+}
+
+func (t alterPartitionReassignmentsResponseV0TopicError) size() int32 {
+	return sizeofCompactString(t.Name) +
+		sizeofCompactArray(len(t.PartitionErrors), func(i int) int32 { return t.PartitionErrors[i].size() }) +
+		sizeofTaggedFields()
+}
+
+func (t alterPartitionReassignmentsResponseV0TopicError) writeTo(w *bufio.Writer) {
+	writeCompactString(w, t.Name)
+	writeCompactArray(w, len(t.PartitionErrors), func(i int) { t.PartitionErrors[i].writeTo(w) })
+	writeTaggedFields(w)
+}
+
+func (t *alterPartitionReassignmentsResponseV0TopicError) readFrom(r *bufio.Reader, size int) (remain int, err error) {
+	if remain, err = readCompactString(r, size, &t.Name); err != nil {
+		return
+	}
+	remain, err = readCompactArrayWith(r, remain, func(r *bufio.Reader, size int) (remain int, err error) {
+		var partition alterPartitionReassignmentsResponseV0PartitionError
+		remain, err = (&partition).readFrom(r, size)
+		t.PartitionErrors = append(t.PartitionErrors, partition)
+		return
+	})
+	if err != nil {
+		return
+	}
+	if remain, err = readTaggedFields(r, remain); err != nil {
+		return
+	}
+	return
+}
+
+// See http://kafka.apache.org/protocol.html#The_Messages_AlterPartitionReassignments
+type alterPartitionReassignmentsResponseV0 struct {
+	ThrottleTimeMS int32
+
+	// ErrorCode holds the top level error code for the whole request, such
+	// as NOT_CONTROLLER, or 0 on success.
+	ErrorCode int16
+
+	// ErrorMessage holds the top level error message, or the empty string.
+	ErrorMessage string
+
+	// TopicErrors holds the per-topic, per-partition results.
+	TopicErrors []alterPartitionReassignmentsResponseV0TopicError
+}
+
+func (t alterPartitionReassignmentsResponseV0) size() int32 {
+	return sizeofInt32(t.ThrottleTimeMS) +
+		sizeofInt16(t.ErrorCode) +
+		sizeofCompactString(t.ErrorMessage) +
+		sizeofCompactArray(len(t.TopicErrors), func(i int) int32 { return t.TopicErrors[i].size() }) +
+		sizeofTaggedFields()
+}
+
+func (t alterPartitionReassignmentsResponseV0) writeTo(w *bufio.Writer) {
+	writeInt32(w, t.ThrottleTimeMS)
+	writeInt16(w, t.ErrorCode)
+	writeCompactString(w, t.ErrorMessage)
+	writeCompactArray(w, len(t.TopicErrors), func(i int) { t.TopicErrors[i].writeTo(w) })
+	writeTaggedFields(w)
+}
+
+func (t *alterPartitionReassignmentsResponseV0) readFrom(r *bufio.Reader, size int) (remain int, err error) {
+	if remain, err = readInt32(r, size, &t.ThrottleTimeMS); err != nil {
+		return
+	}
+	if remain, err = readInt16(r, remain, &t.ErrorCode); err != nil {
+		return
+	}
+	if remain, err = readCompactString(r, remain, &t.ErrorMessage); err != nil {
+		return
+	}
+	remain, err = readCompactArrayWith(r, remain, func(r *bufio.Reader, size int) (remain int, err error) {
+		var topic alterPartitionReassignmentsResponseV0TopicError
+		remain, err = (&topic).readFrom(r, size)
+		t.TopicErrors = append(t.TopicErrors, topic)
+		return
+	})
+	if err != nil {
+		return
+	}
+	if remain, err = readTaggedFields(r, remain); err != nil {
+		return
+	}
+	return
+}
+
+// AlterPartitionReassignmentsRequest represents a request sent to a kafka
+// broker to alter in-progress partition reassignments, as described in
+// KIP-455.
+type AlterPartitionReassignmentsRequest struct {
+	// Assignments is the set of per-partition reassignments to submit. A nil
+	// Replicas slice cancels a pending reassignment for that partition.
+	Assignments []AlterPartitionReassignmentsRequestAssignment
+
+	// Timeout is the amount of time to wait for the request to complete on
+	// the controller. A zero value means the server-default timeout is used.
+	Timeout time.Duration
+}
+
+// AlterPartitionReassignmentsRequestAssignment describes the desired replica
+// set of a single topic-partition.
+type AlterPartitionReassignmentsRequestAssignment struct {
+	Topic     string
+	Partition int
+	Replicas  []int32
+}
+
+// AlterPartitionReassignmentsResponse represents a response from a kafka
+// broker to an AlterPartitionReassignmentsRequest.
+type AlterPartitionReassignmentsResponse struct {
+	// Errors holds the per-partition error, if any, keyed by topic and
+	// partition.
+	Errors []AlterPartitionReassignmentsResponseError
+}
+
+// AlterPartitionReassignmentsResponseError carries the broker's response to
+// a single requested AlterPartitionReassignmentsRequestAssignment.
+type AlterPartitionReassignmentsResponseError struct {
+	Topic        string
+	Partition    int
+	Error        error
+	ErrorMessage string
+}
+
+func (c *Conn) alterPartitionReassignments(request AlterPartitionReassignmentsRequest) (AlterPartitionReassignmentsResponse, error) {
+	byTopic := map[string][]alterPartitionReassignmentsRequestV0ReplicaAssignment{}
+	var order []string
+	for _, a := range request.Assignments {
+		if _, ok := byTopic[a.Topic]; !ok {
+			order = append(order, a.Topic)
+		}
+		byTopic[a.Topic] = append(byTopic[a.Topic], alterPartitionReassignmentsRequestV0ReplicaAssignment{
+			Partition: int32(a.Partition),
+			Replicas:  a.Replicas,
+		})
+	}
+
+	topics := make([]alterPartitionReassignmentsRequestV0Topic, 0, len(order))
+	for _, name := range order {
+		topics = append(topics, alterPartitionReassignmentsRequestV0Topic{
+			Name:       name,
+			Partitions: byTopic[name],
+		})
+	}
+
+	req := alterPartitionReassignmentsRequestV0{
+		TimeoutMS: milliseconds(request.Timeout),
+		Topics:    topics,
+	}
+
+	var response alterPartitionReassignmentsResponseV0
+	var result AlterPartitionReassignmentsResponse
+
+	_, span := startRequestSpan(context.Background(), c.tracer(), "kafka.alter_partition_reassignments", c.RemoteAddr().String(), alterPartitionReassignmentsRequest, v0, 0)
+	defer func() { finishRequestSpan(span, "", -1, nil) }()
+
+	err := c.readOperation(
+		func(deadline time.Time, id int32) error {
+			w := &c.wbuf
+			writeHeader(w, c.clientID, alterPartitionReassignmentsRequest, v0, id, req.size())
+			req.writeTo(w)
+			return w.Flush()
+		},
+		func(deadline time.Time, size int) error {
+			return expectZeroSize(func() (remain int, err error) {
+				return (&response).readFrom(&c.rbuf, size)
+			}())
+		},
+	)
+	if err != nil {
+		span.SetError(err)
+		return result, err
+	}
+	if response.ErrorCode != 0 {
+		err := Error(response.ErrorCode)
+		span.SetError(err)
+		return result, err
+	}
+
+	for _, topic := range response.TopicErrors {
+		for _, partition := range topic.PartitionErrors {
+			var rerr error
+			if partition.ErrorCode != 0 {
+				rerr = Error(partition.ErrorCode)
+			}
+			result.Errors = append(result.Errors, AlterPartitionReassignmentsResponseError{
+				Topic:        topic.Name,
+				Partition:    int(partition.Partition),
+				Error:        rerr,
+				ErrorMessage: partition.ErrorMessage,
+			})
+		}
+	}
+
+	return result, nil
+}
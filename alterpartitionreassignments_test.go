@@ -0,0 +1,64 @@
+package kafka
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestAlterPartitionReassignmentsResponseV0(t *testing.T) {
+	item := alterPartitionReassignmentsResponseV0{
+		ThrottleTimeMS: 1,
+		TopicErrors: []alterPartitionReassignmentsResponseV0TopicError{
+			{
+				Name: "topic",
+				PartitionErrors: []alterPartitionReassignmentsResponseV0PartitionError{
+					{Partition: 0, ErrorCode: 0, ErrorMessage: ""},
+					{Partition: 1, ErrorCode: 2, ErrorMessage: "partition error"},
+				},
+			},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	w := bufio.NewWriter(buf)
+	item.writeTo(w)
+	w.Flush()
+
+	var found alterPartitionReassignmentsResponseV0
+	remain, err := (&found).readFrom(bufio.NewReader(buf), buf.Len())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remain != 0 {
+		t.Fatalf("expected 0 remain, got %v", remain)
+	}
+	if !reflect.DeepEqual(item, found) {
+		t.Fatalf("expected %+v, got %+v", item, found)
+	}
+}
+
+func TestAlterPartitionReassignmentsRequestV0SizeMatchesWrite(t *testing.T) {
+	req := alterPartitionReassignmentsRequestV0{
+		TimeoutMS: 1000,
+		Topics: []alterPartitionReassignmentsRequestV0Topic{
+			{
+				Name: "topic",
+				Partitions: []alterPartitionReassignmentsRequestV0ReplicaAssignment{
+					{Partition: 0, Replicas: []int32{1, 2, 3}},
+					{Partition: 1, Replicas: nil}, // cancel a pending reassignment
+				},
+			},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	w := bufio.NewWriter(buf)
+	req.writeTo(w)
+	w.Flush()
+
+	if int32(buf.Len()) != req.size() {
+		t.Fatalf("size() = %d, wrote %d bytes", req.size(), buf.Len())
+	}
+}
@@ -0,0 +1,92 @@
+package kafka
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+)
+
+// wrapCompressed encodes msgs as an inner message set, compresses it with
+// codec, and returns the single wrapper message that should be written in
+// their place. The wrapper carries the compression codec in the low 3 bits
+// of its Attributes, per
+// http://kafka.apache.org/protocol.html#protocol_message_sets
+func wrapCompressed(codec CompressionCodec, msgs []message) (message, error) {
+	inner := &bytes.Buffer{}
+	w := bufio.NewWriter(inner)
+
+	for i, msg := range msgs {
+		// Inner messages carry their relative offset within the wrapper
+		// message set, starting at 0, rather than an absolute offset; the
+		// broker (or, on the consume side, decompressMessageSet below)
+		// rewrites these once the wrapper's own offset is known.
+		msg.Offset = int64(i)
+		messageSetItem{Offset: msg.Offset, Message: msg}.writeTo(w)
+	}
+	if err := w.Flush(); err != nil {
+		return message{}, err
+	}
+
+	compressed, err := codec.Encode(inner.Bytes())
+	if err != nil {
+		return message{}, err
+	}
+
+	return message{
+		Attributes: codec.Code() & compressionCodecMask,
+		Value:      compressed,
+	}, nil
+}
+
+// decompressMessageSet decompresses wrapper's Value with the codec selected
+// by its Attributes and returns the inner messages it contains, with their
+// offsets rewritten from the relative offsets used inside a compressed
+// message set to absolute offsets anchored at wrapper's own Offset.
+//
+// Per the Kafka protocol, the last inner message's relative offset is the
+// count of inner messages minus one, so absolute offsets are derived by
+// walking backwards from wrapper.Offset.
+func decompressMessageSet(wrapper message) ([]message, error) {
+	codec, err := resolveCodec(wrapper.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	if codec == nil {
+		return []message{wrapper}, nil
+	}
+
+	decompressed, err := codec.Decode(wrapper.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	r := bufio.NewReader(bytes.NewReader(decompressed))
+
+	var items []messageSetItem
+	remain := len(decompressed)
+	for remain > 0 {
+		var item messageSetItem
+		n, err := (&item).readFrom(r, remain)
+		if err != nil {
+			return nil, fmt.Errorf("kafka: malformed compressed message set: %w", err)
+		}
+		if n >= remain {
+			return nil, fmt.Errorf("kafka: malformed compressed message set: inner message did not shrink the remaining size")
+		}
+		remain = n
+		items = append(items, item)
+	}
+
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	messages := make([]message, len(items))
+	lastRelative := items[len(items)-1].Offset
+	for i, item := range items {
+		messages[i] = item.Message
+		messages[i].Offset = wrapper.Offset - (lastRelative - item.Offset)
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,130 @@
+package kafka
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/snappy"
+)
+
+func testCodecRoundTrip(t *testing.T, codec CompressionCodec) {
+	t.Helper()
+
+	src := []byte("the quick brown fox jumps over the lazy dog, the quick brown fox jumps over the lazy dog")
+
+	encoded, err := codec.Encode(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := codec.Decode(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(decoded, src) {
+		t.Fatalf("round trip mismatch: got %q, want %q", decoded, src)
+	}
+}
+
+func TestGzipCodecRoundTrip(t *testing.T) {
+	testCodecRoundTrip(t, &gzipCodec{})
+}
+
+func TestSnappyCodecRoundTrip(t *testing.T) {
+	testCodecRoundTrip(t, &snappyCodec{})
+}
+
+func TestLZ4CodecRoundTrip(t *testing.T) {
+	testCodecRoundTrip(t, &lz4Codec{})
+}
+
+func TestZstdCodecRoundTrip(t *testing.T) {
+	testCodecRoundTrip(t, &zstdCodec{})
+}
+
+func TestSnappyCodecDecodesPlainSnappy(t *testing.T) {
+	// Decode must also accept snappy data with no xerial framing, since not
+	// every producer uses it.
+	codec := &snappyCodec{}
+
+	src := []byte("plain, unframed snappy payload")
+	encoded := snappy.Encode(nil, src)
+
+	decoded, err := codec.Decode(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded, src) {
+		t.Fatalf("round trip mismatch: got %q, want %q", decoded, src)
+	}
+}
+
+func TestWrapAndDecompressMessageSetRewritesOffsets(t *testing.T) {
+	codec := &gzipCodec{}
+
+	msgs := []message{
+		{Key: []byte("k1"), Value: []byte("v1")},
+		{Key: []byte("k2"), Value: []byte("v2")},
+		{Key: []byte("k3"), Value: []byte("v3")},
+	}
+
+	wrapped, err := wrapCompressed(codec, msgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The broker assigns the wrapper message the absolute offset of its
+	// last inner message.
+	wrapped.Offset = 41
+
+	inner, err := decompressMessageSet(wrapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(inner) != len(msgs) {
+		t.Fatalf("expected %d inner messages, got %d", len(msgs), len(inner))
+	}
+
+	wantOffsets := []int64{39, 40, 41}
+	for i, m := range inner {
+		if m.Offset != wantOffsets[i] {
+			t.Errorf("message %d: expected offset %d, got %d", i, wantOffsets[i], m.Offset)
+		}
+		if !bytes.Equal(m.Value, msgs[i].Value) {
+			t.Errorf("message %d: expected value %q, got %q", i, msgs[i].Value, m.Value)
+		}
+	}
+}
+
+func TestDecompressMessageSetEmptyInner(t *testing.T) {
+	codec := &gzipCodec{}
+
+	wrapped, err := wrapCompressed(codec, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inner, err := decompressMessageSet(wrapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inner) != 0 {
+		t.Fatalf("expected no inner messages, got %d", len(inner))
+	}
+}
+
+func TestDecompressMessageSetCorruptInnerIsAnError(t *testing.T) {
+	codec := &gzipCodec{}
+
+	encoded, err := codec.Encode([]byte("not a valid message set"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wrapper := message{Attributes: codec.Code(), Value: encoded}
+	if _, err := decompressMessageSet(wrapper); err == nil {
+		t.Fatal("expected an error decoding a corrupt inner message set, got nil")
+	}
+}
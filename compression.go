@@ -0,0 +1,69 @@
+package kafka
+
+import (
+	"fmt"
+)
+
+// Compression attribute bits, as stored in the lowest 3 bits of a message
+// set's Attributes field. See
+// http://kafka.apache.org/protocol.html#protocol_message_sets
+const (
+	compressionNone   int8 = 0
+	compressionGZIP   int8 = 1
+	compressionSnappy int8 = 2
+	compressionLZ4    int8 = 3
+	compressionZstd   int8 = 4
+
+	compressionCodecMask int8 = 0x07
+)
+
+// CompressionCodec represents a compression codec to encode and decode
+// the messages.
+//
+// A CompressionCodec implementation doesn't need to be safe for concurrent
+// use by multiple goroutines, callers are expected to use separate codec
+// instances if they need to encode/decode concurrently.
+type CompressionCodec interface {
+	// Code returns the compression codec code, as stored in the
+	// Attributes field of a message set, to identify which algorithm
+	// was used to compress it.
+	Code() int8
+
+	// Encode returns the compressed form of src.
+	Encode(src []byte) ([]byte, error)
+
+	// Decode returns the decompressed form of src.
+	Decode(src []byte) ([]byte, error)
+}
+
+// compressionCodecs is the registry of codecs that RegisterCompressionCodec
+// installs into, keyed by the Code() they report. Fetch responses look up
+// the codec to use here based on the compression bits of a message's
+// Attributes.
+var compressionCodecs = map[int8]CompressionCodec{}
+
+// RegisterCompressionCodec registers a compression codec so it can be used
+// to decompress messages whose Attributes carry its code. The registry is
+// only consulted when decoding: compress and wrapCompressed take the codec
+// to encode with as an argument, and no Writer or Conn setting selects one.
+// Codecs for gzip, snappy, lz4, and zstd are registered automatically; call
+// this to plug in additional implementations, or to replace the built-in
+// one for a given code.
+func RegisterCompressionCodec(codec CompressionCodec) {
+	compressionCodecs[codec.Code()] = codec
+}
+
+// resolveCodec returns the codec registered for attribute's compression
+// bits, or an error if attribute requests compression but no codec was
+// registered for it.
+func resolveCodec(attributes int8) (CompressionCodec, error) {
+	code := attributes & compressionCodecMask
+	if code == compressionNone {
+		return nil, nil
+	}
+	codec, ok := compressionCodecs[code]
+	if !ok {
+		return nil, fmt.Errorf("kafka: unsupported compression code: %d", code)
+	}
+	return codec, nil
+}
@@ -0,0 +1,167 @@
+package kafka
+
+import (
+	"bufio"
+	"context"
+	"time"
+)
+
+type createPartitionsRequestV0Assignment struct {
+	// BrokerIDs lists the brokers the new partition's replicas should be
+	// placed on.
+	BrokerIDs []int32
+}
+
+func (t createPartitionsRequestV0Assignment) size() int32 {
+	return sizeofArray(len(t.BrokerIDs), func(i int) int32 { return sizeofInt32(t.BrokerIDs[i]) })
+}
+
+func (t createPartitionsRequestV0Assignment) writeTo(w *bufio.Writer) {
+	writeArray(w, len(t.BrokerIDs), func(i int) { writeInt32(w, t.BrokerIDs[i]) })
+}
+
+type createPartitionsRequestV0Topic struct {
+	// Topic is the name of the topic to add partitions to.
+	Topic string
+
+	// Count is the new total partition count for the topic, including the
+	// partitions it already has.
+	Count int32
+
+	// Assignment optionally assigns replicas for the newly added
+	// partitions. A nil slice lets the broker choose.
+	Assignment []createPartitionsRequestV0Assignment
+}
+
+func (t createPartitionsRequestV0Topic) size() int32 {
+	return sizeofString(t.Topic) +
+		sizeofInt32(t.Count) +
+		sizeofArray(len(t.Assignment), func(i int) int32 { return t.Assignment[i].size() })
+}
+
+func (t createPartitionsRequestV0Topic) writeTo(w *bufio.Writer) {
+	writeString(w, t.Topic)
+	writeInt32(w, t.Count)
+	writeArray(w, len(t.Assignment), func(i int) { t.Assignment[i].writeTo(w) })
+}
+
+// See http://kafka.apache.org/protocol.html#The_Messages_CreatePartitions
+type createPartitionsRequestV0 struct {
+	Topics []createPartitionsRequestV0Topic
+
+	// Timeout ms to wait for the new partitions to be created on the
+	// controller node.
+	Timeout int32
+
+	// ValidateOnly if true, the request is validated but partitions are not
+	// actually created.
+	ValidateOnly bool
+}
+
+func (t createPartitionsRequestV0) size() int32 {
+	return sizeofArray(len(t.Topics), func(i int) int32 { return t.Topics[i].size() }) +
+		sizeofInt32(t.Timeout) +
+		sizeofBool(t.ValidateOnly)
+}
+
+func (t createPartitionsRequestV0) writeTo(w *bufio.Writer) {
+	writeArray(w, len(t.Topics), func(i int) { t.Topics[i].writeTo(w) })
+	writeInt32(w, t.Timeout)
+	writeBool(w, t.ValidateOnly)
+}
+
+type createPartitionsResponseV0TopicError struct {
+	Topic        string
+	ErrorCode    int16
+	ErrorMessage string
+}
+
+func (t createPartitionsResponseV0TopicError) size() int32 {
+	return sizeofString(t.Topic) +
+		sizeofInt16(t.ErrorCode) +
+		sizeofString(t.ErrorMessage)
+}
+
+func (t createPartitionsResponseV0TopicError) writeTo(w *bufio.Writer) {
+	writeString(w, t.Topic)
+	writeInt16(w, t.ErrorCode)
+	writeString(w, t.ErrorMessage)
+}
+
+func (t *createPartitionsResponseV0TopicError) readFrom(r *bufio.Reader, size int) (remain int, err error) {
+	if remain, err = readString(r, size, &t.Topic); err != nil {
+		return
+	}
+	if remain, err = readInt16(r, remain, &t.ErrorCode); err != nil {
+		return
+	}
+	if remain, err = readString(r, remain, &t.ErrorMessage); err != nil {
+		return
+	}
+	return
+}
+
+// See http://kafka.apache.org/protocol.html#The_Messages_CreatePartitions
+type createPartitionsResponseV0 struct {
+	ThrottleTimeMS int32
+	TopicErrors    []createPartitionsResponseV0TopicError
+}
+
+func (t createPartitionsResponseV0) size() int32 {
+	return sizeofInt32(t.ThrottleTimeMS) +
+		sizeofArray(len(t.TopicErrors), func(i int) int32 { return t.TopicErrors[i].size() })
+}
+
+func (t createPartitionsResponseV0) writeTo(w *bufio.Writer) {
+	writeInt32(w, t.ThrottleTimeMS)
+	writeArray(w, len(t.TopicErrors), func(i int) { t.TopicErrors[i].writeTo(w) })
+}
+
+func (t *createPartitionsResponseV0) readFrom(r *bufio.Reader, size int) (remain int, err error) {
+	if remain, err = readInt32(r, size, &t.ThrottleTimeMS); err != nil {
+		return
+	}
+
+	remain, err = readArrayWith(r, remain, func(r *bufio.Reader, size int) (remain int, err error) {
+		var topic createPartitionsResponseV0TopicError
+		remain, err = (&topic).readFrom(r, size)
+		t.TopicErrors = append(t.TopicErrors, topic)
+		return
+	})
+
+	return
+}
+
+func (c *Conn) createPartitions(request createPartitionsRequestV0) (createPartitionsResponseV0, error) {
+	var response createPartitionsResponseV0
+
+	_, span := startRequestSpan(context.Background(), c.tracer(), "kafka.create_partitions", c.RemoteAddr().String(), createPartitionsRequest, v0, 0)
+	defer func() { finishRequestSpan(span, "", -1, nil) }()
+
+	err := c.readOperation(
+		func(deadline time.Time, id int32) error {
+			w := &c.wbuf
+			writeHeader(w, c.clientID, createPartitionsRequest, v0, id, request.size())
+			request.writeTo(w)
+			return w.Flush()
+		},
+		func(deadline time.Time, size int) error {
+			return expectZeroSize(func() (remain int, err error) {
+				return (&response).readFrom(&c.rbuf, size)
+			}())
+		},
+	)
+	if err != nil {
+		span.SetError(err)
+		return response, err
+	}
+	for _, tr := range response.TopicErrors {
+		if tr.ErrorCode != 0 {
+			err := Error(tr.ErrorCode)
+			span.SetError(err)
+			return response, err
+		}
+	}
+
+	return response, nil
+}
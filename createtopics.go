@@ -0,0 +1,39 @@
+package kafka
+
+import (
+	"context"
+	"time"
+)
+
+func (c *Conn) createTopics(request createTopicsRequestV2) (createTopicsResponseV2, error) {
+	var response createTopicsResponseV2
+
+	ctx, span := startRequestSpan(context.Background(), c.tracer(), "kafka.create_topics", c.RemoteAddr().String(), createTopicsRequest, v2, 0)
+	defer func() { finishRequestSpan(span, "", -1, nil) }()
+	_ = ctx
+
+	err := c.readOperation(
+		func(deadline time.Time, id int32) error {
+			w := &c.wbuf
+			writeHeader(w, c.clientID, createTopicsRequest, v2, id, request.size())
+			request.writeTo(w)
+			return w.Flush()
+		},
+		func(deadline time.Time, size int) error {
+			return expectZeroSize(func() (remain int, err error) {
+				return (&response).readFrom(&c.rbuf, size)
+			}())
+		},
+	)
+	if err != nil {
+		span.SetError(err)
+		return response, err
+	}
+	for _, tr := range response.TopicErrors {
+		if tr.ErrorCode != 0 {
+			return response, Error(tr.ErrorCode)
+		}
+	}
+
+	return response, nil
+}
@@ -5,7 +5,6 @@ import (
 	"bytes"
 	"reflect"
 	"testing"
-	"time"
 )
 
 type createTopicsRequestV2ConfigEntry struct {
@@ -25,17 +24,20 @@ func (t createTopicsRequestV2ConfigEntry) writeTo(w *bufio.Writer) {
 
 type createTopicsRequestV2ReplicaAssignment struct {
 	Partition int32
-	Replicas  int32
+
+	// Replicas lists the broker IDs to assign as replicas of Partition, in
+	// order (the first entry is the preferred leader).
+	Replicas []int32
 }
 
 func (t createTopicsRequestV2ReplicaAssignment) size() int32 {
 	return sizeofInt32(t.Partition) +
-		sizeofInt32(t.Replicas)
+		sizeofInt32Array(t.Replicas)
 }
 
 func (t createTopicsRequestV2ReplicaAssignment) writeTo(w *bufio.Writer) {
 	writeInt32(w, t.Partition)
-	writeInt32(w, t.Replicas)
+	writeInt32Array(w, t.Replicas)
 }
 
 type createTopicsRequestV2Topic struct {
@@ -199,43 +201,19 @@ func TestCreateTopicsResponseV2(t *testing.T) {
 	}
 }
 
-func writeHeader(w *bufio.Writer, clientID string, apiKey apiKey, apiVersion apiVersion, correlationID, size int32) {
-	h := requestHeader{
-		ApiKey:        int16(apiKey),
-		ApiVersion:    int16(apiVersion),
-		CorrelationID: correlationID,
-		ClientID:      clientID,
+func TestCreateTopicsRequestV2ReplicaAssignmentSizeMatchesWrite(t *testing.T) {
+	item := createTopicsRequestV2ReplicaAssignment{
+		Partition: 0,
+		Replicas:  []int32{1, 2, 3},
 	}
-	h.Size = h.size() - 4 + size
-
-	// write message
-	h.writeTo(w)
-}
 
-func (c *Conn) createTopics(request createTopicsRequestV2) (createTopicsResponseV2, error) {
-	var response createTopicsResponseV2
+	buf := bytes.NewBuffer(nil)
+	w := bufio.NewWriter(buf)
+	item.writeTo(w)
+	w.Flush()
 
-	err := c.readOperation(
-		func(deadline time.Time, id int32) error {
-			w := &c.wbuf
-			writeHeader(w, c.clientID, createTopicsRequest, v2, id, request.size())
-			request.writeTo(w)
-			return w.Flush()
-		},
-		func(deadline time.Time, size int) error {
-			return expectZeroSize(func() (remain int, err error) {
-				return (&response).readFrom(&c.rbuf, size)
-			}())
-		},
-	)
-	if err != nil {
-		return response, err
+	if int32(buf.Len()) != item.size() {
+		t.Errorf("size() returned %d, but writeTo wrote %d bytes", item.size(), buf.Len())
 	}
-	for _, tr := range response.TopicErrors {
-		if tr.ErrorCode != 0 {
-			return response, Error(tr.ErrorCode)
-		}
-	}
-
-	return response, nil
 }
+
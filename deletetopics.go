@@ -0,0 +1,121 @@
+package kafka
+
+import (
+	"bufio"
+	"context"
+	"time"
+)
+
+// See http://kafka.apache.org/protocol.html#The_Messages_DeleteTopics
+type deleteTopicsRequestV1 struct {
+	// Topics holds the names of the topics to delete.
+	Topics []string
+
+	// Timeout ms to wait for a topic to be completely deleted on the
+	// controller node. Values <= 0 will trigger topic deletion and return
+	// immediately.
+	Timeout int32
+}
+
+func (t deleteTopicsRequestV1) size() int32 {
+	return sizeofArray(len(t.Topics), func(i int) int32 { return sizeofString(t.Topics[i]) }) +
+		sizeofInt32(t.Timeout)
+}
+
+func (t deleteTopicsRequestV1) writeTo(w *bufio.Writer) {
+	writeArray(w, len(t.Topics), func(i int) { writeString(w, t.Topics[i]) })
+	writeInt32(w, t.Timeout)
+}
+
+type deleteTopicsResponseV1TopicError struct {
+	// Topic name
+	Topic string
+
+	// ErrorCode holds response error code
+	ErrorCode int16
+}
+
+func (t deleteTopicsResponseV1TopicError) size() int32 {
+	return sizeofString(t.Topic) +
+		sizeofInt16(t.ErrorCode)
+}
+
+func (t deleteTopicsResponseV1TopicError) writeTo(w *bufio.Writer) {
+	writeString(w, t.Topic)
+	writeInt16(w, t.ErrorCode)
+}
+
+func (t *deleteTopicsResponseV1TopicError) readFrom(r *bufio.Reader, size int) (remain int, err error) {
+	if remain, err = readString(r, size, &t.Topic); err != nil {
+		return
+	}
+	if remain, err = readInt16(r, remain, &t.ErrorCode); err != nil {
+		return
+	}
+	return
+}
+
+// See http://kafka.apache.org/protocol.html#The_Messages_DeleteTopics
+type deleteTopicsResponseV1 struct {
+	ThrottleTimeMS int32
+	TopicErrors    []deleteTopicsResponseV1TopicError
+}
+
+func (t deleteTopicsResponseV1) size() int32 {
+	return sizeofInt32(t.ThrottleTimeMS) +
+		sizeofArray(len(t.TopicErrors), func(i int) int32 { return t.TopicErrors[i].size() })
+}
+
+func (t deleteTopicsResponseV1) writeTo(w *bufio.Writer) {
+	writeInt32(w, t.ThrottleTimeMS)
+	writeArray(w, len(t.TopicErrors), func(i int) { t.TopicErrors[i].writeTo(w) })
+}
+
+func (t *deleteTopicsResponseV1) readFrom(r *bufio.Reader, size int) (remain int, err error) {
+	if remain, err = readInt32(r, size, &t.ThrottleTimeMS); err != nil {
+		return
+	}
+
+	remain, err = readArrayWith(r, remain, func(r *bufio.Reader, size int) (remain int, err error) {
+		var topic deleteTopicsResponseV1TopicError
+		remain, err = (&topic).readFrom(r, size)
+		t.TopicErrors = append(t.TopicErrors, topic)
+		return
+	})
+
+	return
+}
+
+func (c *Conn) deleteTopics(request deleteTopicsRequestV1) (deleteTopicsResponseV1, error) {
+	var response deleteTopicsResponseV1
+
+	_, span := startRequestSpan(context.Background(), c.tracer(), "kafka.delete_topics", c.RemoteAddr().String(), deleteTopicsRequest, v1, 0)
+	defer func() { finishRequestSpan(span, "", -1, nil) }()
+
+	err := c.readOperation(
+		func(deadline time.Time, id int32) error {
+			w := &c.wbuf
+			writeHeader(w, c.clientID, deleteTopicsRequest, v1, id, request.size())
+			request.writeTo(w)
+			return w.Flush()
+		},
+		func(deadline time.Time, size int) error {
+			return expectZeroSize(func() (remain int, err error) {
+				return (&response).readFrom(&c.rbuf, size)
+			}())
+		},
+	)
+	if err != nil {
+		span.SetError(err)
+		return response, err
+	}
+	for _, tr := range response.TopicErrors {
+		if tr.ErrorCode != 0 {
+			err := Error(tr.ErrorCode)
+			span.SetError(err)
+			return response, err
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,169 @@
+package kafka
+
+import (
+	"bufio"
+	"context"
+	"time"
+)
+
+type describeConfigsRequestV0Resource struct {
+	// ResourceType is the type of resource being described, e.g.
+	// ResourceTypeTopic or ResourceTypeBroker.
+	ResourceType int8
+
+	// ResourceName is the topic name, or the broker ID as a string.
+	ResourceName string
+
+	// ConfigNames restricts the response to these configuration keys. A nil
+	// slice (encoded as a null array) requests every config for the
+	// resource; a non-nil empty slice requests none.
+	ConfigNames []string
+}
+
+func (t describeConfigsRequestV0Resource) size() int32 {
+	return sizeofInt8(t.ResourceType) +
+		sizeofString(t.ResourceName) +
+		sizeofConfigNames(t.ConfigNames)
+}
+
+func (t describeConfigsRequestV0Resource) writeTo(w *bufio.Writer) {
+	writeInt8(w, t.ResourceType)
+	writeString(w, t.ResourceName)
+	writeConfigNames(w, t.ConfigNames)
+}
+
+// sizeofConfigNames and writeConfigNames encode ConfigNames as a classic
+// (non-flexible) Kafka nullable array: a nil slice is written as a null
+// array (length -1), matching Kafka's "omit this field to get every config"
+// semantics, which plain sizeofArray/writeArray can't express since they
+// treat a nil and an empty slice identically.
+func sizeofConfigNames(names []string) int32 {
+	if names == nil {
+		return sizeofInt32(-1)
+	}
+	return sizeofArray(len(names), func(i int) int32 { return sizeofString(names[i]) })
+}
+
+func writeConfigNames(w *bufio.Writer, names []string) {
+	if names == nil {
+		writeInt32(w, -1)
+		return
+	}
+	writeArray(w, len(names), func(i int) { writeString(w, names[i]) })
+}
+
+// See http://kafka.apache.org/protocol.html#The_Messages_DescribeConfigs
+type describeConfigsRequestV0 struct {
+	Resources []describeConfigsRequestV0Resource
+}
+
+func (t describeConfigsRequestV0) size() int32 {
+	return sizeofArray(len(t.Resources), func(i int) int32 { return t.Resources[i].size() })
+}
+
+func (t describeConfigsRequestV0) writeTo(w *bufio.Writer) {
+	writeArray(w, len(t.Resources), func(i int) { t.Resources[i].writeTo(w) })
+}
+
+type describeConfigsResponseV0ConfigEntry struct {
+	ConfigName  string
+	ConfigValue string
+	ReadOnly    bool
+	IsDefault   bool
+	IsSensitive bool
+}
+
+func (t *describeConfigsResponseV0ConfigEntry) readFrom(r *bufio.Reader, size int) (remain int, err error) {
+	if remain, err = readString(r, size, &t.ConfigName); err != nil {
+		return
+	}
+	if remain, err = readString(r, remain, &t.ConfigValue); err != nil {
+		return
+	}
+	if remain, err = readBool(r, remain, &t.ReadOnly); err != nil {
+		return
+	}
+	if remain, err = readBool(r, remain, &t.IsDefault); err != nil {
+		return
+	}
+	if remain, err = readBool(r, remain, &t.IsSensitive); err != nil {
+		return
+	}
+	return
+}
+
+type describeConfigsResponseV0Resource struct {
+	ErrorCode     int16
+	ErrorMessage  string
+	ResourceType  int8
+	ResourceName  string
+	ConfigEntries []describeConfigsResponseV0ConfigEntry
+}
+
+func (t *describeConfigsResponseV0Resource) readFrom(r *bufio.Reader, size int) (remain int, err error) {
+	if remain, err = readInt16(r, size, &t.ErrorCode); err != nil {
+		return
+	}
+	if remain, err = readString(r, remain, &t.ErrorMessage); err != nil {
+		return
+	}
+	if remain, err = readInt8(r, remain, &t.ResourceType); err != nil {
+		return
+	}
+	if remain, err = readString(r, remain, &t.ResourceName); err != nil {
+		return
+	}
+	remain, err = readArrayWith(r, remain, func(r *bufio.Reader, size int) (remain int, err error) {
+		var entry describeConfigsResponseV0ConfigEntry
+		remain, err = (&entry).readFrom(r, size)
+		t.ConfigEntries = append(t.ConfigEntries, entry)
+		return
+	})
+	return
+}
+
+// See http://kafka.apache.org/protocol.html#The_Messages_DescribeConfigs
+type describeConfigsResponseV0 struct {
+	ThrottleTimeMS int32
+	Resources      []describeConfigsResponseV0Resource
+}
+
+func (t *describeConfigsResponseV0) readFrom(r *bufio.Reader, size int) (remain int, err error) {
+	if remain, err = readInt32(r, size, &t.ThrottleTimeMS); err != nil {
+		return
+	}
+	remain, err = readArrayWith(r, remain, func(r *bufio.Reader, size int) (remain int, err error) {
+		var resource describeConfigsResponseV0Resource
+		remain, err = (&resource).readFrom(r, size)
+		t.Resources = append(t.Resources, resource)
+		return
+	})
+	return
+}
+
+func (c *Conn) describeConfigs(request describeConfigsRequestV0) (describeConfigsResponseV0, error) {
+	var response describeConfigsResponseV0
+
+	_, span := startRequestSpan(context.Background(), c.tracer(), "kafka.describe_configs", c.RemoteAddr().String(), describeConfigsRequest, v0, 0)
+	defer func() { finishRequestSpan(span, "", -1, nil) }()
+
+	err := c.readOperation(
+		func(deadline time.Time, id int32) error {
+			w := &c.wbuf
+			writeHeader(w, c.clientID, describeConfigsRequest, v0, id, request.size())
+			request.writeTo(w)
+			return w.Flush()
+		},
+		func(deadline time.Time, size int) error {
+			return expectZeroSize(func() (remain int, err error) {
+				return (&response).readFrom(&c.rbuf, size)
+			}())
+		},
+	)
+	if err != nil {
+		span.SetError(err)
+		return response, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,25 @@
+package kafka
+
+// decompressMessages expands any compressed wrapper messages in msgs (as
+// produced by compress in writer_compress.go) into their inner messages, rewriting offsets
+// along the way. It's meant to be called on every message read off the wire,
+// before handing messages to the caller, so compressed batches are
+// transparently decompressed regardless of which codec the producer used,
+// as long as that codec was registered via RegisterCompressionCodec.
+//
+// Nothing in this tree calls decompressMessages yet: the fetch/consume read
+// path (Conn's fetch path, or a higher-level Reader) isn't present here, so
+// there's nowhere to plug it in without inventing that code from scratch.
+func decompressMessages(msgs []message) ([]message, error) {
+	var out []message
+
+	for _, msg := range msgs {
+		inner, err := decompressMessageSet(msg)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, inner...)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,42 @@
+package kafka
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+)
+
+func init() {
+	RegisterCompressionCodec(&gzipCodec{})
+}
+
+// gzipCodec implements the CompressionCodec interface and supports
+// compressing/decompressing message sets with the DEFLATE algorithm, as used
+// by the Kafka Java client.
+type gzipCodec struct{}
+
+func (c *gzipCodec) Code() int8 {
+	return compressionGZIP
+}
+
+func (c *gzipCodec) Encode(src []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(src); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *gzipCodec) Decode(src []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
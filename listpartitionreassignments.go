@@ -0,0 +1,333 @@
+package kafka
+
+import (
+	"bufio"
+	"context"
+	"time"
+)
+
+// See https://cwiki.apache.org/confluence/display/KAFKA/KIP-455%3A+Create+an+Administrative+API+for+Replica+Reassignment
+
+type listPartitionReassignmentsRequestV0Topic struct {
+	// Name is the topic name.
+	Name string
+
+	// PartitionIndexes lists the partitions of Name to report on. A nil
+	// slice (encoded as a null compact array) requests all partitions of
+	// the topic; a non-nil empty slice is encoded as an empty array.
+	PartitionIndexes []int32
+}
+
+func (t listPartitionReassignmentsRequestV0Topic) size() int32 {
+	return sizeofCompactString(t.Name) +
+		sizeofCompactInt32Array(t.PartitionIndexes) +
+		sizeofTaggedFields()
+}
+
+func (t listPartitionReassignmentsRequestV0Topic) writeTo(w *bufio.Writer) {
+	writeCompactString(w, t.Name)
+	writeCompactInt32Array(w, t.PartitionIndexes)
+	writeTaggedFields(w)
+}
+
+// listPartitionReassignmentsRequestV0 is a flexible (KIP-482) request, see
+// http://kafka.apache.org/protocol.html#The_Messages_ListPartitionReassignments
+type listPartitionReassignmentsRequestV0 struct {
+	// TimeoutMS is how long to wait, in milliseconds, for the request to
+	// complete on the controller before timing out.
+	TimeoutMS int32
+
+	// Topics lists which topics (and optionally which of their partitions)
+	// to report on. A nil slice requests every reassignment in progress on
+	// the cluster.
+	Topics []listPartitionReassignmentsRequestV0Topic
+}
+
+func (t listPartitionReassignmentsRequestV0) size() int32 {
+	return sizeofInt32(t.TimeoutMS) +
+		listPartitionReassignmentsSizeofTopics(t.Topics) +
+		sizeofTaggedFields()
+}
+
+func (t listPartitionReassignmentsRequestV0) writeTo(w *bufio.Writer) {
+	writeInt32(w, t.TimeoutMS)
+	listPartitionReassignmentsWriteTopics(w, t.Topics)
+	writeTaggedFields(w)
+}
+
+// listPartitionReassignmentsSizeofTopics and
+// listPartitionReassignmentsWriteTopics encode Topics as a compact array,
+// with a nil slice encoded as a null compact array meaning "all topics".
+func listPartitionReassignmentsSizeofTopics(topics []listPartitionReassignmentsRequestV0Topic) int32 {
+	if topics == nil {
+		return sizeofUvarint(0)
+	}
+	return sizeofCompactArray(len(topics), func(i int) int32 { return topics[i].size() })
+}
+
+func listPartitionReassignmentsWriteTopics(w *bufio.Writer, topics []listPartitionReassignmentsRequestV0Topic) {
+	if topics == nil {
+		writeUvarint(w, 0)
+		return
+	}
+	writeCompactArray(w, len(topics), func(i int) { topics[i].writeTo(w) })
+}
+
+type listPartitionReassignmentsResponseV0PartitionReassignment struct {
+	// Partition is the partition being reassigned.
+	Partition int32
+
+	// Replicas is the partition's current full replica set.
+	Replicas []int32
+
+	// AddingReplicas lists the replicas being added as part of the
+	// reassignment.
+	AddingReplicas []int32
+
+	// RemovingReplicas lists the replicas being removed as part of the
+	// reassignment.
+	RemovingReplicas []int32
+}
+
+func (t listPartitionReassignmentsResponseV0PartitionReassignment) size() int32 {
+	return sizeofInt32(t.Partition) +
+		sizeofCompactInt32Array(t.Replicas) +
+		sizeofCompactInt32Array(t.AddingReplicas) +
+		sizeofCompactInt32Array(t.RemovingReplicas) +
+		sizeofTaggedFields()
+}
+
+func (t listPartitionReassignmentsResponseV0PartitionReassignment) writeTo(w *bufio.Writer) {
+	writeInt32(w, t.Partition)
+	writeCompactInt32Array(w, t.Replicas)
+	writeCompactInt32Array(w, t.AddingReplicas)
+	writeCompactInt32Array(w, t.RemovingReplicas)
+	writeTaggedFields(w)
+}
+
+func (t *listPartitionReassignmentsResponseV0PartitionReassignment) readFrom(r *bufio.Reader, size int) (remain int, err error) {
+	if remain, err = readInt32(r, size, &t.Partition); err != nil {
+		return
+	}
+	if remain, err = readCompactInt32Array(r, remain, &t.Replicas); err != nil {
+		return
+	}
+	if remain, err = readCompactInt32Array(r, remain, &t.AddingReplicas); err != nil {
+		return
+	}
+	if remain, err = readCompactInt32Array(r, remain, &t.RemovingReplicas); err != nil {
+		return
+	}
+	if remain, err = readTaggedFields(r, remain); err != nil {
+		return
+	}
+	return
+}
+
+type listPartitionReassignmentsResponseV0TopicReassignment struct {
+	// Name is the topic name.
+	Name string
+
+	// Partitions holds the in-progress reassignments for this topic.
+	Partitions []listPartitionReassignmentsResponseV0PartitionReassignment
+}
+
+func (t listPartitionReassignmentsResponseV0TopicReassignment) size() int32 {
+	return sizeofCompactString(t.Name) +
+		sizeofCompactArray(len(t.Partitions), func(i int) int32 { return t.Partitions[i].size() }) +
+		sizeofTaggedFields()
+}
+
+func (t listPartitionReassignmentsResponseV0TopicReassignment) writeTo(w *bufio.Writer) {
+	writeCompactString(w, t.Name)
+	writeCompactArray(w, len(t.Partitions), func(i int) { t.Partitions[i].writeTo(w) })
+	writeTaggedFields(w)
+}
+
+func (t *listPartitionReassignmentsResponseV0TopicReassignment) readFrom(r *bufio.Reader, size int) (remain int, err error) {
+	if remain, err = readCompactString(r, size, &t.Name); err != nil {
+		return
+	}
+	remain, err = readCompactArrayWith(r, remain, func(r *bufio.Reader, size int) (remain int, err error) {
+		var partition listPartitionReassignmentsResponseV0PartitionReassignment
+		remain, err = (&partition).readFrom(r, size)
+		t.Partitions = append(t.Partitions, partition)
+		return
+	})
+	if err != nil {
+		return
+	}
+	if remain, err = readTaggedFields(r, remain); err != nil {
+		return
+	}
+	return
+}
+
+// See http://kafka.apache.org/protocol.html#The_Messages_ListPartitionReassignments
+type listPartitionReassignmentsResponseV0 struct {
+	ThrottleTimeMS int32
+
+	// ErrorCode holds the top level error code for the whole request, such
+	// as NOT_CONTROLLER, or 0 on success.
+	ErrorCode int16
+
+	// ErrorMessage holds the top level error message, or the empty string.
+	ErrorMessage string
+
+	// TopicReassignments holds the currently active reassignments, per
+	// topic and partition.
+	TopicReassignments []listPartitionReassignmentsResponseV0TopicReassignment
+}
+
+func (t listPartitionReassignmentsResponseV0) size() int32 {
+	return sizeofInt32(t.ThrottleTimeMS) +
+		sizeofInt16(t.ErrorCode) +
+		sizeofCompactString(t.ErrorMessage) +
+		sizeofCompactArray(len(t.TopicReassignments), func(i int) int32 { return t.TopicReassignments[i].size() }) +
+		sizeofTaggedFields()
+}
+
+func (t listPartitionReassignmentsResponseV0) writeTo(w *bufio.Writer) {
+	writeInt32(w, t.ThrottleTimeMS)
+	writeInt16(w, t.ErrorCode)
+	writeCompactString(w, t.ErrorMessage)
+	writeCompactArray(w, len(t.TopicReassignments), func(i int) { t.TopicReassignments[i].writeTo(w) })
+	writeTaggedFields(w)
+}
+
+func (t *listPartitionReassignmentsResponseV0) readFrom(r *bufio.Reader, size int) (remain int, err error) {
+	if remain, err = readInt32(r, size, &t.ThrottleTimeMS); err != nil {
+		return
+	}
+	if remain, err = readInt16(r, remain, &t.ErrorCode); err != nil {
+		return
+	}
+	if remain, err = readCompactString(r, remain, &t.ErrorMessage); err != nil {
+		return
+	}
+	remain, err = readCompactArrayWith(r, remain, func(r *bufio.Reader, size int) (remain int, err error) {
+		var topic listPartitionReassignmentsResponseV0TopicReassignment
+		remain, err = (&topic).readFrom(r, size)
+		t.TopicReassignments = append(t.TopicReassignments, topic)
+		return
+	})
+	if err != nil {
+		return
+	}
+	if remain, err = readTaggedFields(r, remain); err != nil {
+		return
+	}
+	return
+}
+
+// ListPartitionReassignmentsRequest represents a request sent to a kafka
+// broker to list the partition reassignments currently in progress, as
+// described in KIP-455.
+type ListPartitionReassignmentsRequest struct {
+	// Topics restricts the results to the named topics/partitions. A nil
+	// slice requests every in-progress reassignment on the cluster.
+	Topics []ListPartitionReassignmentsRequestTopic
+
+	// Timeout is the amount of time to wait for the request to complete on
+	// the controller. A zero value means the server-default timeout is used.
+	Timeout time.Duration
+}
+
+// ListPartitionReassignmentsRequestTopic restricts a
+// ListPartitionReassignmentsRequest to a topic, and optionally to a subset
+// of its partitions.
+type ListPartitionReassignmentsRequestTopic struct {
+	Topic string
+
+	// Partitions restricts the results to these partitions. A nil/empty
+	// slice requests all partitions of Topic.
+	Partitions []int
+}
+
+// ListPartitionReassignmentsResponse represents a response from a kafka
+// broker to a ListPartitionReassignmentsRequest.
+type ListPartitionReassignmentsResponse struct {
+	// Reassignments holds the currently active reassignments.
+	Reassignments []PartitionReassignment
+}
+
+// PartitionReassignment describes the in-progress reassignment of a single
+// topic-partition.
+type PartitionReassignment struct {
+	Topic            string
+	Partition        int
+	Replicas         []int32
+	AddingReplicas   []int32
+	RemovingReplicas []int32
+}
+
+func (c *Conn) listPartitionReassignments(request ListPartitionReassignmentsRequest) (ListPartitionReassignmentsResponse, error) {
+	var topics []listPartitionReassignmentsRequestV0Topic
+	if request.Topics != nil {
+		topics = make([]listPartitionReassignmentsRequestV0Topic, len(request.Topics))
+		for i, t := range request.Topics {
+			// A nil/empty Partitions means "all partitions of this topic",
+			// which PartitionIndexes encodes the same way Partitions does
+			// here: as a nil slice, not a zero-length one.
+			var partitions []int32
+			if len(t.Partitions) > 0 {
+				partitions = make([]int32, len(t.Partitions))
+				for j, p := range t.Partitions {
+					partitions[j] = int32(p)
+				}
+			}
+			topics[i] = listPartitionReassignmentsRequestV0Topic{
+				Name:             t.Topic,
+				PartitionIndexes: partitions,
+			}
+		}
+	}
+
+	req := listPartitionReassignmentsRequestV0{
+		TimeoutMS: milliseconds(request.Timeout),
+		Topics:    topics,
+	}
+
+	var response listPartitionReassignmentsResponseV0
+	var result ListPartitionReassignmentsResponse
+
+	_, span := startRequestSpan(context.Background(), c.tracer(), "kafka.list_partition_reassignments", c.RemoteAddr().String(), listPartitionReassignmentsRequest, v0, 0)
+	defer func() { finishRequestSpan(span, "", -1, nil) }()
+
+	err := c.readOperation(
+		func(deadline time.Time, id int32) error {
+			w := &c.wbuf
+			writeHeader(w, c.clientID, listPartitionReassignmentsRequest, v0, id, req.size())
+			req.writeTo(w)
+			return w.Flush()
+		},
+		func(deadline time.Time, size int) error {
+			return expectZeroSize(func() (remain int, err error) {
+				return (&response).readFrom(&c.rbuf, size)
+			}())
+		},
+	)
+	if err != nil {
+		span.SetError(err)
+		return result, err
+	}
+	if response.ErrorCode != 0 {
+		err := Error(response.ErrorCode)
+		span.SetError(err)
+		return result, err
+	}
+
+	for _, topic := range response.TopicReassignments {
+		for _, partition := range topic.Partitions {
+			result.Reassignments = append(result.Reassignments, PartitionReassignment{
+				Topic:            topic.Name,
+				Partition:        int(partition.Partition),
+				Replicas:         partition.Replicas,
+				AddingReplicas:   partition.AddingReplicas,
+				RemovingReplicas: partition.RemovingReplicas,
+			})
+		}
+	}
+
+	return result, nil
+}
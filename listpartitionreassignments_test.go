@@ -0,0 +1,63 @@
+package kafka
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestListPartitionReassignmentsResponseV0(t *testing.T) {
+	item := listPartitionReassignmentsResponseV0{
+		ThrottleTimeMS: 1,
+		TopicReassignments: []listPartitionReassignmentsResponseV0TopicReassignment{
+			{
+				Name: "topic",
+				Partitions: []listPartitionReassignmentsResponseV0PartitionReassignment{
+					{
+						Partition:        0,
+						Replicas:         []int32{1, 2, 3},
+						AddingReplicas:   []int32{3},
+						RemovingReplicas: nil,
+					},
+				},
+			},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	w := bufio.NewWriter(buf)
+	item.writeTo(w)
+	w.Flush()
+
+	var found listPartitionReassignmentsResponseV0
+	remain, err := (&found).readFrom(bufio.NewReader(buf), buf.Len())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remain != 0 {
+		t.Fatalf("expected 0 remain, got %v", remain)
+	}
+	if !reflect.DeepEqual(item, found) {
+		t.Fatalf("expected %+v, got %+v", item, found)
+	}
+}
+
+func TestListPartitionReassignmentsRequestV0NilTopicsMeansAll(t *testing.T) {
+	req := listPartitionReassignmentsRequestV0{TimeoutMS: 1000, Topics: nil}
+
+	buf := bytes.NewBuffer(nil)
+	w := bufio.NewWriter(buf)
+	req.writeTo(w)
+	w.Flush()
+
+	if int32(buf.Len()) != req.size() {
+		t.Fatalf("size() = %d, wrote %d bytes", req.size(), buf.Len())
+	}
+
+	// Topics follows the 4 byte TimeoutMS; a null compact array is a single
+	// zero length byte.
+	if topics := buf.Bytes()[4]; topics != 0 {
+		t.Fatalf("expected nil Topics to be encoded as a null compact array (0), got %d", topics)
+	}
+}
@@ -0,0 +1,39 @@
+package kafka
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/pierrec/lz4"
+)
+
+func init() {
+	RegisterCompressionCodec(&lz4Codec{})
+}
+
+// lz4Codec implements the CompressionCodec interface and supports
+// compressing/decompressing message sets with LZ4 framing (v1), as used by
+// Kafka's LZ4 compression type.
+type lz4Codec struct{}
+
+func (c *lz4Codec) Code() int8 {
+	return compressionLZ4
+}
+
+func (c *lz4Codec) Encode(src []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := lz4.NewWriter(&buf)
+	if _, err := w.Write(src); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *lz4Codec) Decode(src []byte) ([]byte, error) {
+	r := lz4.NewReader(bytes.NewReader(src))
+	return ioutil.ReadAll(r)
+}
@@ -0,0 +1,185 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PoolConfig configures a ConnPool.
+type PoolConfig struct {
+	// PerBrokerConns is the number of connections to keep open to each
+	// broker. Requests against a broker round-robin across this many
+	// connections instead of each caller dialing its own. Defaults to 1.
+	PerBrokerConns int
+
+	// MaxIdle is how long a pooled connection may sit unused before it's
+	// closed and removed from the pool. Zero means connections are never
+	// closed for being idle.
+	MaxIdle time.Duration
+
+	// DialTimeout bounds how long dialing a new connection to a broker may
+	// take. Zero means no timeout.
+	DialTimeout time.Duration
+
+	// Dialer is used to establish new connections. Defaults to DefaultDialer.
+	Dialer *Dialer
+}
+
+func (config *PoolConfig) validate() {
+	if config.PerBrokerConns <= 0 {
+		config.PerBrokerConns = 1
+	}
+	if config.Dialer == nil {
+		config.Dialer = DefaultDialer
+	}
+}
+
+// ConnPool multiplexes Conn usage for a set of brokers across a small, fixed
+// number of connections per broker, rather than requiring callers to open
+// one Conn each. This is intended for high fan-out scenarios where many
+// producers or consumers would otherwise each dial their own connection to
+// the same brokers.
+type ConnPool struct {
+	config PoolConfig
+
+	mutex   sync.Mutex
+	brokers map[string]*brokerConns
+}
+
+// brokerConns is the fixed set of connections the pool keeps open to a
+// single broker, plus round-robin and idle-tracking state.
+type brokerConns struct {
+	mutex sync.Mutex
+	conns []*pooledConn
+	next  int
+}
+
+type pooledConn struct {
+	conn     *Conn
+	inUse    int
+	lastIdle time.Time
+}
+
+// NewConnPool creates a ConnPool using the given configuration.
+func NewConnPool(config PoolConfig) *ConnPool {
+	config.validate()
+	return &ConnPool{
+		config:  config,
+		brokers: make(map[string]*brokerConns),
+	}
+}
+
+// Get returns a Conn to use against the given broker address (host:port),
+// along with a release function the caller must invoke once it's done with
+// the connection. The pool opens at most PerBrokerConns connections per
+// broker and round-robins callers across them rather than opening a new
+// connection per call.
+func (p *ConnPool) Get(ctx context.Context, broker string) (conn *Conn, release func(), err error) {
+	bc := p.brokerConnsFor(broker)
+
+	bc.mutex.Lock()
+	if len(bc.conns) < p.config.PerBrokerConns {
+		dialCtx := ctx
+		var cancel context.CancelFunc
+		if p.config.DialTimeout > 0 {
+			dialCtx, cancel = context.WithTimeout(ctx, p.config.DialTimeout)
+		}
+		c, dialErr := p.config.Dialer.DialContext(dialCtx, "tcp", broker)
+		if cancel != nil {
+			cancel()
+		}
+		if dialErr != nil {
+			bc.mutex.Unlock()
+			return nil, nil, dialErr
+		}
+		bc.conns = append(bc.conns, &pooledConn{conn: c})
+	}
+	bc.mutex.Unlock()
+
+	pc, release := bc.acquire()
+	return pc.conn, release, nil
+}
+
+// acquire round-robins across bc.conns, returning the chosen conn's inUse
+// count already incremented, and a release func the caller must invoke once
+// it's done with the connection, which decrements inUse again and records
+// when it became idle.
+func (bc *brokerConns) acquire() (*pooledConn, func()) {
+	bc.mutex.Lock()
+	pc := bc.conns[bc.next%len(bc.conns)]
+	bc.next++
+	pc.inUse++
+	bc.mutex.Unlock()
+
+	release := func() {
+		bc.mutex.Lock()
+		pc.inUse--
+		if pc.inUse == 0 {
+			pc.lastIdle = time.Now()
+		}
+		bc.mutex.Unlock()
+	}
+
+	return pc, release
+}
+
+func (p *ConnPool) brokerConnsFor(broker string) *brokerConns {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	bc, ok := p.brokers[broker]
+	if !ok {
+		bc = &brokerConns{}
+		p.brokers[broker] = bc
+	}
+	return bc
+}
+
+// Close closes every connection the pool has opened.
+func (p *ConnPool) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var err error
+	for _, bc := range p.brokers {
+		bc.mutex.Lock()
+		for _, pc := range bc.conns {
+			if closeErr := pc.conn.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}
+		bc.mutex.Unlock()
+	}
+	p.brokers = make(map[string]*brokerConns)
+	return err
+}
+
+// CloseIdle closes and removes any connections that have been idle for
+// longer than p.config.MaxIdle. It's intended to be driven by a background
+// goroutine the pool owner runs periodically; ConnPool does not start one
+// itself so that callers control the polling cadence.
+func (p *ConnPool) CloseIdle() {
+	if p.config.MaxIdle <= 0 {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, bc := range p.brokers {
+		bc.mutex.Lock()
+		live := bc.conns[:0]
+		for _, pc := range bc.conns {
+			if pc.inUse == 0 && !pc.lastIdle.IsZero() && time.Since(pc.lastIdle) > p.config.MaxIdle {
+				if pc.conn != nil {
+					pc.conn.Close()
+				}
+				continue
+			}
+			live = append(live, pc)
+		}
+		bc.conns = live
+		bc.mutex.Unlock()
+	}
+}
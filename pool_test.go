@@ -0,0 +1,98 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestPool builds a ConnPool with MaxIdle set but without dialing any
+// real connections, so its round-robin and idle-tracking bookkeeping can be
+// exercised directly against bc.conns.
+func newTestPool(maxIdle time.Duration) *ConnPool {
+	p := NewConnPool(PoolConfig{MaxIdle: maxIdle})
+	return p
+}
+
+func TestConnPoolCloseIdleRemovesOnlyExpiredIdleConns(t *testing.T) {
+	p := newTestPool(10 * time.Millisecond)
+
+	bc := p.brokerConnsFor("broker:9092")
+	bc.conns = []*pooledConn{
+		{lastIdle: time.Now().Add(-time.Hour)}, // idle past MaxIdle, should be closed
+		{lastIdle: time.Now()},                 // idle, but not past MaxIdle yet
+		{inUse: 1},                             // in use, must never be closed
+	}
+
+	p.CloseIdle()
+
+	if len(bc.conns) != 2 {
+		t.Fatalf("expected 2 remaining conns, got %d", len(bc.conns))
+	}
+	for _, pc := range bc.conns {
+		if pc.inUse == 0 && time.Since(pc.lastIdle) > time.Minute {
+			t.Fatalf("an idle-expired conn survived CloseIdle: %+v", pc)
+		}
+	}
+}
+
+func TestConnPoolCloseIdleNoopWhenMaxIdleUnset(t *testing.T) {
+	p := newTestPool(0)
+
+	bc := p.brokerConnsFor("broker:9092")
+	bc.conns = []*pooledConn{
+		{lastIdle: time.Now().Add(-time.Hour)},
+	}
+
+	p.CloseIdle()
+
+	if len(bc.conns) != 1 {
+		t.Fatalf("expected CloseIdle to be a no-op when MaxIdle is unset, got %d conns", len(bc.conns))
+	}
+}
+
+func TestConnPoolBrokerConnsForReusesExistingEntry(t *testing.T) {
+	p := newTestPool(0)
+
+	a := p.brokerConnsFor("broker:9092")
+	b := p.brokerConnsFor("broker:9092")
+
+	if a != b {
+		t.Fatal("expected brokerConnsFor to return the same *brokerConns for repeated calls with the same broker")
+	}
+}
+
+func TestBrokerConnsAcquireRoundRobinsAndReleaseMarksConnIdle(t *testing.T) {
+	p := newTestPool(0)
+
+	bc := p.brokerConnsFor("broker:9092")
+	a := &pooledConn{}
+	b := &pooledConn{}
+	bc.conns = []*pooledConn{a, b}
+
+	pc1, release1 := bc.acquire()
+	pc2, release2 := bc.acquire()
+
+	if pc1 != a || pc2 != b {
+		t.Fatalf("expected acquire to round-robin across conns, got %p then %p", pc1, pc2)
+	}
+	if a.inUse != 1 || b.inUse != 1 {
+		t.Fatalf("expected both conns to have inUse=1, got a=%d b=%d", a.inUse, b.inUse)
+	}
+
+	release1()
+
+	if a.inUse != 0 {
+		t.Fatalf("expected inUse to be 0 after release, got %d", a.inUse)
+	}
+	if a.lastIdle.IsZero() {
+		t.Fatal("expected lastIdle to be set once a conn's inUse count drops to 0")
+	}
+	if b.inUse != 1 {
+		t.Fatalf("expected releasing one conn not to affect the other, got inUse=%d", b.inUse)
+	}
+
+	release2()
+	if b.inUse != 0 {
+		t.Fatalf("expected inUse to be 0 after release, got %d", b.inUse)
+	}
+}
@@ -0,0 +1,255 @@
+package kafka
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+)
+
+// apiKey values for the flexible (KIP-482) admin APIs added on top of the
+// existing createTopics/deleteTopics family. See
+// http://kafka.apache.org/protocol.html#protocol_api_keys
+const (
+	alterPartitionReassignmentsRequest apiKey = 45
+	listPartitionReassignmentsRequest  apiKey = 46
+)
+
+// apiKey values for the classic (non-flexible) admin APIs backing
+// AdminClient's DeleteTopics/DescribeConfigs/AlterConfigs/CreatePartitions.
+const (
+	describeConfigsRequest  apiKey = 32
+	alterConfigsRequest     apiKey = 33
+	createPartitionsRequest apiKey = 37
+)
+
+// Flexible (KIP-482) requests and responses use a tagged-field buffer after
+// their "known" fields to allow additive changes without bumping the API
+// version. We don't currently populate any tags on the way out, but we still
+// have to write the (empty) tag count so brokers that expect a flexible
+// request/response can parse it, and we have to be able to skip over tags
+// that a broker sends back to us.
+
+// writeUvarint writes v as an unsigned base-128 varint, the integer
+// encoding KIP-482 flexible fields (tag/length headers, compact
+// string/array lengths) use on the wire.
+func writeUvarint(w *bufio.Writer, v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	w.Write(b[:n])
+}
+
+// sizeofUvarint returns the number of bytes writeUvarint would write for v.
+func sizeofUvarint(v uint64) int32 {
+	var b [binary.MaxVarintLen64]byte
+	return int32(binary.PutUvarint(b[:], v))
+}
+
+// readUvarint reads an unsigned base-128 varint into *v, returning the
+// number of size bytes remaining afterwards.
+func readUvarint(r *bufio.Reader, size int, v *int) (remain int, err error) {
+	remain = size
+	var x uint64
+	var shift uint
+	for {
+		if remain <= 0 {
+			return remain, fmt.Errorf("kafka: not enough bytes to read a varint")
+		}
+		b, err := r.ReadByte()
+		if err != nil {
+			return remain, err
+		}
+		remain--
+		x |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			*v = int(x)
+			return remain, nil
+		}
+		shift += 7
+	}
+}
+
+// discardN reads and discards n bytes from r, returning the number of size
+// bytes remaining afterwards. Used to skip over tagged fields whose tags we
+// don't understand yet.
+func discardN(r *bufio.Reader, size int, n int) (remain int, err error) {
+	discarded, err := r.Discard(n)
+	remain = size - discarded
+	return
+}
+
+// readStringLength reads exactly n bytes into *s. It's the compact-string
+// counterpart of readString, which instead reads its own length prefix.
+func readStringLength(r *bufio.Reader, size int, n int, s *string) (remain int, err error) {
+	buf := make([]byte, n)
+	if _, err = readFull(r, buf); err != nil {
+		return size, err
+	}
+	*s = string(buf)
+	return size - n, nil
+}
+
+// readFull reads exactly len(buf) bytes from r into buf.
+func readFull(r *bufio.Reader, buf []byte) (int, error) {
+	n := 0
+	for n < len(buf) {
+		c, err := r.Read(buf[n:])
+		n += c
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// writeTaggedFields writes the tagged fields section of a flexible
+// request/response. Callers don't yet have any tags to send, so this always
+// writes a tag count of zero.
+func writeTaggedFields(w *bufio.Writer) {
+	writeUvarint(w, 0)
+}
+
+// readTaggedFields consumes the tagged fields section of a flexible
+// request/response. Since we don't interpret any tags yet, each one is read
+// and discarded.
+func readTaggedFields(r *bufio.Reader, size int) (remain int, err error) {
+	var n int
+	if remain, err = readUvarint(r, size, &n); err != nil {
+		return
+	}
+	for i := 0; i < n; i++ {
+		var tag, length int
+		if remain, err = readUvarint(r, remain, &tag); err != nil {
+			return
+		}
+		if remain, err = readUvarint(r, remain, &length); err != nil {
+			return
+		}
+		if remain, err = discardN(r, remain, length); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// sizeofTaggedFields returns the encoded size of an empty tagged fields
+// section (just the zero tag count).
+func sizeofTaggedFields() int32 {
+	return sizeofUvarint(0)
+}
+
+// writeCompactString writes a KIP-482 compact string: an unsigned varint
+// length (N+1, where N is the byte length, with 0 meaning null) followed by
+// the raw bytes.
+func writeCompactString(w *bufio.Writer, s string) {
+	writeUvarint(w, uint64(len(s))+1)
+	w.WriteString(s)
+}
+
+// sizeofCompactString returns the encoded size of a compact string.
+func sizeofCompactString(s string) int32 {
+	return sizeofUvarint(uint64(len(s))+1) + int32(len(s))
+}
+
+// readCompactString reads a KIP-482 compact string into s.
+func readCompactString(r *bufio.Reader, size int, s *string) (remain int, err error) {
+	var n int
+	if remain, err = readUvarint(r, size, &n); err != nil {
+		return
+	}
+	if n == 0 {
+		*s = ""
+		return
+	}
+	return readStringLength(r, remain, n-1, s)
+}
+
+// writeCompactArray writes a KIP-482 compact array header (length N+1, with 0
+// meaning null), then invokes write for each element.
+func writeCompactArray(w *bufio.Writer, n int, write func(int)) {
+	writeUvarint(w, uint64(n)+1)
+	for i := 0; i < n; i++ {
+		write(i)
+	}
+}
+
+// sizeofCompactArray returns the encoded size of a compact array header plus
+// the sizes of its elements as reported by size.
+func sizeofCompactArray(n int, size func(int) int32) int32 {
+	total := sizeofUvarint(uint64(n) + 1)
+	for i := 0; i < n; i++ {
+		total += size(i)
+	}
+	return total
+}
+
+// readCompactArrayWith reads a KIP-482 compact array header and invokes read
+// once per element, mirroring readArrayWith's non-compact counterpart.
+func readCompactArrayWith(r *bufio.Reader, size int, read func(*bufio.Reader, int) (int, error)) (remain int, err error) {
+	var n int
+	if remain, err = readUvarint(r, size, &n); err != nil {
+		return
+	}
+	if n == 0 {
+		return
+	}
+	// Each element takes at least one byte on the wire, so a count that
+	// doesn't fit in what's left of the frame means the broker sent a
+	// corrupt (or hostile) length rather than a real array this large.
+	if count := n - 1; count < 0 || count > remain {
+		return remain, fmt.Errorf("kafka: compact array length %d exceeds remaining size %d", count, remain)
+	}
+	for i := 0; i < n-1; i++ {
+		if remain, err = read(r, remain); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// sizeofCompactInt32Array and writeCompactInt32Array encode a []int32 as a
+// KIP-482 compact array, with a nil slice encoded as a null compact array
+// (length 0). Used by the partition-reassignment APIs, where a null replica
+// list has request-specific meaning (e.g. "cancel this reassignment").
+func sizeofCompactInt32Array(values []int32) int32 {
+	if values == nil {
+		return sizeofUvarint(0)
+	}
+	return sizeofCompactArray(len(values), func(i int) int32 { return sizeofInt32(values[i]) })
+}
+
+func writeCompactInt32Array(w *bufio.Writer, values []int32) {
+	if values == nil {
+		writeUvarint(w, 0)
+		return
+	}
+	writeCompactArray(w, len(values), func(i int) { writeInt32(w, values[i]) })
+}
+
+// readCompactInt32Array reads a KIP-482 compact array of int32 into *out.
+func readCompactInt32Array(r *bufio.Reader, size int, out *[]int32) (remain int, err error) {
+	var n int
+	if remain, err = readUvarint(r, size, &n); err != nil {
+		return
+	}
+	if n == 0 {
+		*out = nil
+		return
+	}
+	// Bound the claimed element count against what's actually left in the
+	// frame (each int32 is 4 bytes) before allocating, so a broker response
+	// with a corrupt or hostile length can't trigger a huge or negative
+	// allocation.
+	count := n - 1
+	if count < 0 || int64(count)*4 > int64(remain) {
+		err = fmt.Errorf("kafka: compact int32 array length %d exceeds remaining size %d", count, remain)
+		return
+	}
+	values := make([]int32, count)
+	for i := range values {
+		if remain, err = readInt32(r, remain, &values[i]); err != nil {
+			return
+		}
+	}
+	*out = values
+	return
+}
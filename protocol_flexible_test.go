@@ -0,0 +1,108 @@
+package kafka
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+)
+
+func TestUvarintRoundTrip(t *testing.T) {
+	for _, v := range []uint64{0, 1, 2, 127, 128, 300, 1 << 20} {
+		buf := &bytes.Buffer{}
+		w := bufio.NewWriter(buf)
+		writeUvarint(w, v)
+		w.Flush()
+
+		if int32(buf.Len()) != sizeofUvarint(v) {
+			t.Fatalf("sizeofUvarint(%d) = %d, wrote %d bytes", v, sizeofUvarint(v), buf.Len())
+		}
+
+		var got int
+		remain, err := readUvarint(bufio.NewReader(buf), buf.Len(), &got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if remain != 0 {
+			t.Fatalf("expected 0 remain, got %d", remain)
+		}
+		if uint64(got) != v {
+			t.Fatalf("expected %d, got %d", v, got)
+		}
+	}
+}
+
+func TestCompactStringRoundTrip(t *testing.T) {
+	for _, s := range []string{"", "topic", "a longer topic name"} {
+		buf := &bytes.Buffer{}
+		w := bufio.NewWriter(buf)
+		writeCompactString(w, s)
+		w.Flush()
+
+		if int32(buf.Len()) != sizeofCompactString(s) {
+			t.Fatalf("sizeofCompactString(%q) = %d, wrote %d bytes", s, sizeofCompactString(s), buf.Len())
+		}
+
+		var got string
+		remain, err := readCompactString(bufio.NewReader(buf), buf.Len(), &got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if remain != 0 {
+			t.Fatalf("expected 0 remain, got %d", remain)
+		}
+		if got != s {
+			t.Fatalf("expected %q, got %q", s, got)
+		}
+	}
+}
+
+func TestCompactInt32ArrayRoundTrip(t *testing.T) {
+	tests := [][]int32{nil, {}, {1, 2, 3}}
+
+	for _, values := range tests {
+		buf := &bytes.Buffer{}
+		w := bufio.NewWriter(buf)
+		writeCompactInt32Array(w, values)
+		w.Flush()
+
+		if int32(buf.Len()) != sizeofCompactInt32Array(values) {
+			t.Fatalf("sizeofCompactInt32Array(%v) = %d, wrote %d bytes", values, sizeofCompactInt32Array(values), buf.Len())
+		}
+
+		var got []int32
+		remain, err := readCompactInt32Array(bufio.NewReader(buf), buf.Len(), &got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if remain != 0 {
+			t.Fatalf("expected 0 remain, got %d", remain)
+		}
+		if len(got) != len(values) {
+			t.Fatalf("expected %v, got %v", values, got)
+		}
+		for i := range values {
+			if got[i] != values[i] {
+				t.Fatalf("expected %v, got %v", values, got)
+			}
+		}
+	}
+}
+
+func TestTaggedFieldsRoundTrip(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := bufio.NewWriter(buf)
+	writeTaggedFields(w)
+	w.Flush()
+
+	if int32(buf.Len()) != sizeofTaggedFields() {
+		t.Fatalf("sizeofTaggedFields() = %d, wrote %d bytes", sizeofTaggedFields(), buf.Len())
+	}
+
+	remain, err := readTaggedFields(bufio.NewReader(buf), buf.Len())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remain != 0 {
+		t.Fatalf("expected 0 remain, got %d", remain)
+	}
+}
@@ -0,0 +1,38 @@
+package kafka
+
+import (
+	"bufio"
+)
+
+// headerVersion reports the request header version to use for apiKey/
+// apiVersion, per the table in http://kafka.apache.org/protocol.html#protocol_messages.
+// Flexible (KIP-482) requests use header v2, which appends an empty tagged
+// fields buffer after the client ID; everything else uses v1.
+func headerVersion(key apiKey, version apiVersion) int16 {
+	switch key {
+	case alterPartitionReassignmentsRequest, listPartitionReassignmentsRequest:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func writeHeader(w *bufio.Writer, clientID string, apiKey apiKey, apiVersion apiVersion, correlationID, size int32) {
+	h := requestHeader{
+		ApiKey:        int16(apiKey),
+		ApiVersion:    int16(apiVersion),
+		CorrelationID: correlationID,
+		ClientID:      clientID,
+	}
+	hv := headerVersion(apiKey, apiVersion)
+	h.Size = h.size() - 4 + size
+	if hv >= 2 {
+		h.Size += sizeofTaggedFields()
+	}
+
+	// write message
+	h.writeTo(w)
+	if hv >= 2 {
+		writeTaggedFields(w)
+	}
+}
@@ -0,0 +1,133 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+)
+
+// Span represents a single unit of work started by a Tracer, e.g. one
+// Kafka RPC or the processing of one consumed message.
+type Span interface {
+	// SetTag attaches a key/value tag to the span, such as
+	// "messaging.kafka.partition".
+	SetTag(key string, value interface{})
+
+	// SetError records that the operation the span represents failed,
+	// typically because the broker returned a non-zero error code.
+	SetError(err error)
+
+	// Finish marks the span as complete.
+	Finish()
+}
+
+// Tracer starts spans around the admin RPCs issued by a Conn (createTopics,
+// deleteTopics, createPartitions, describeConfigs, alterConfigs and the
+// partition reassignment calls), and can inject/extract span context
+// to/from record headers so a trace started by a producer can be continued
+// by a consumer. Produce, fetch and metadata requests do not start spans.
+// A Conn with no Tracer registered uses noopTracer, which does nothing.
+type Tracer interface {
+	// StartSpan starts and returns a new span for the named operation
+	// (e.g. "kafka.create_topics", "kafka.delete_topics"), as a child of
+	// any span found in ctx.
+	StartSpan(ctx context.Context, operationName string) (context.Context, Span)
+
+	// Inject serializes the span context carried by ctx into headers, so
+	// that a consumer can continue the trace via Extract.
+	Inject(ctx context.Context, headers []Header) []Header
+
+	// Extract deserializes a span context from headers, returning a
+	// context carrying it so a subsequent StartSpan call produces a child
+	// span of the one that produced the message.
+	Extract(ctx context.Context, headers []Header) context.Context
+}
+
+// Standard span tags, following the OpenTracing semantic conventions used
+// by messaging systems.
+const (
+	tagMessagingSystem      = "messaging.system"
+	tagMessagingDestination = "messaging.destination"
+	tagMessagingPartition   = "messaging.kafka.partition"
+	tagPeerAddress          = "peer.address"
+	tagAPIKey               = "kafka.api_key"
+	tagAPIVersion           = "kafka.api_version"
+	tagCorrelationID        = "kafka.correlation_id"
+)
+
+// noopTracer is the Tracer a Conn uses when none was registered for it via
+// SetTracer; Dialer has no Tracer setting. Every method is a no-op so Conn
+// can call through the Tracer interface unconditionally.
+type noopTracer struct{}
+
+func (noopTracer) StartSpan(ctx context.Context, operationName string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+func (noopTracer) Inject(ctx context.Context, headers []Header) []Header {
+	return headers
+}
+
+func (noopTracer) Extract(ctx context.Context, headers []Header) context.Context {
+	return ctx
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetTag(key string, value interface{}) {}
+func (noopSpan) SetError(err error)                    {}
+func (noopSpan) Finish()                               {}
+
+// tracers holds the Tracer registered for each Conn via SetTracer. Conn has
+// no Tracer field of its own, so this sits alongside it rather than on it;
+// entries are small (one interface value per traced Conn) and stay until
+// RemoveTracer is called; closing a Conn does not remove its entry.
+var tracers sync.Map // map[*Conn]Tracer
+
+// SetTracer installs tracer as the Tracer used for every RPC issued by conn.
+// Call RemoveTracer when conn is closed to avoid leaking the registration.
+func SetTracer(conn *Conn, tracer Tracer) {
+	tracers.Store(conn, tracer)
+}
+
+// RemoveTracer removes any Tracer registered for conn via SetTracer.
+func RemoveTracer(conn *Conn) {
+	tracers.Delete(conn)
+}
+
+// tracer returns the Tracer registered for c via SetTracer, or noopTracer{}
+// if none was set, so callers can invoke the interface unconditionally.
+func (c *Conn) tracer() Tracer {
+	if t, ok := tracers.Load(c); ok {
+		return t.(Tracer)
+	}
+	return noopTracer{}
+}
+
+// startRequestSpan starts a span for a single Kafka RPC and tags it with the
+// standard messaging attributes, plus the api key/version and the given
+// correlationID. The Conn admin methods start their span before the request
+// is written, when the wire correlation ID is not yet assigned, and pass 0.
+func startRequestSpan(ctx context.Context, tracer Tracer, operationName, broker string, key apiKey, version apiVersion, correlationID int32) (context.Context, Span) {
+	ctx, span := tracer.StartSpan(ctx, operationName)
+	span.SetTag(tagMessagingSystem, "kafka")
+	span.SetTag(tagPeerAddress, broker)
+	span.SetTag(tagAPIKey, int16(key))
+	span.SetTag(tagAPIVersion, int16(version))
+	span.SetTag(tagCorrelationID, correlationID)
+	return ctx, span
+}
+
+// finishRequestSpan tags span with the destination topic/partition this RPC
+// applied to (when known) and any broker-reported error, then finishes it.
+func finishRequestSpan(span Span, topic string, partition int, err error) {
+	if topic != "" {
+		span.SetTag(tagMessagingDestination, topic)
+	}
+	if partition >= 0 {
+		span.SetTag(tagMessagingPartition, partition)
+	}
+	if err != nil {
+		span.SetError(err)
+	}
+	span.Finish()
+}
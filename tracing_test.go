@@ -0,0 +1,97 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+)
+
+type recordingSpan struct {
+	tags     map[string]interface{}
+	err      error
+	finished bool
+}
+
+func (s *recordingSpan) SetTag(key string, value interface{}) { s.tags[key] = value }
+func (s *recordingSpan) SetError(err error)                   { s.err = err }
+func (s *recordingSpan) Finish()                              { s.finished = true }
+
+type recordingTracer struct {
+	started []string
+	span    *recordingSpan
+}
+
+func (t *recordingTracer) StartSpan(ctx context.Context, operationName string) (context.Context, Span) {
+	t.started = append(t.started, operationName)
+	t.span = &recordingSpan{tags: map[string]interface{}{}}
+	return ctx, t.span
+}
+
+func (t *recordingTracer) Inject(ctx context.Context, headers []Header) []Header {
+	return headers
+}
+
+func (t *recordingTracer) Extract(ctx context.Context, headers []Header) context.Context {
+	return ctx
+}
+
+func TestConnTracerDefaultsToNoop(t *testing.T) {
+	c := &Conn{}
+	if _, ok := c.tracer().(noopTracer); !ok {
+		t.Fatalf("expected a Conn with no registered tracer to use noopTracer, got %T", c.tracer())
+	}
+}
+
+func TestSetTracerAndRemoveTracer(t *testing.T) {
+	c := &Conn{}
+	tracer := &recordingTracer{}
+
+	SetTracer(c, tracer)
+	if got := c.tracer(); got != Tracer(tracer) {
+		t.Fatalf("expected c.tracer() to return the registered tracer, got %T", got)
+	}
+
+	RemoveTracer(c)
+	if _, ok := c.tracer().(noopTracer); !ok {
+		t.Fatalf("expected c.tracer() to fall back to noopTracer after RemoveTracer, got %T", c.tracer())
+	}
+}
+
+func TestSetTracerIsPerConn(t *testing.T) {
+	a, b := &Conn{}, &Conn{}
+	tracer := &recordingTracer{}
+
+	SetTracer(a, tracer)
+	defer RemoveTracer(a)
+
+	if _, ok := b.tracer().(noopTracer); !ok {
+		t.Fatalf("expected an unrelated Conn to still use noopTracer, got %T", b.tracer())
+	}
+}
+
+func TestStartAndFinishRequestSpan(t *testing.T) {
+	tracer := &recordingTracer{}
+
+	ctx, span := startRequestSpan(context.Background(), tracer, "kafka.create_topics", "broker:9092", createTopicsRequest, v2, 7)
+	finishRequestSpan(span, "my-topic", 3, nil)
+
+	if len(tracer.started) != 1 || tracer.started[0] != "kafka.create_topics" {
+		t.Fatalf("expected StartSpan to be called once with the operation name, got %v", tracer.started)
+	}
+	if ctx == nil {
+		t.Fatal("expected startRequestSpan to return a non-nil context")
+	}
+
+	rs := tracer.span
+	if !rs.finished {
+		t.Fatal("expected finishRequestSpan to finish the span")
+	}
+	if rs.tags[tagMessagingDestination] != "my-topic" {
+		t.Fatalf("expected %s tag to be set, got %v", tagMessagingDestination, rs.tags[tagMessagingDestination])
+	}
+	if rs.tags[tagMessagingPartition] != 3 {
+		t.Fatalf("expected %s tag to be set, got %v", tagMessagingPartition, rs.tags[tagMessagingPartition])
+	}
+	if rs.err != nil {
+		t.Fatalf("expected no error to be recorded, got %v", rs.err)
+	}
+}
@@ -0,0 +1,22 @@
+package kafka
+
+// compress wraps msgs in a single compressed message using codec, or
+// returns msgs unmodified if codec is nil. It's meant to be called on each
+// batch before writing it to the wire, by whatever assembles the produce
+// request for a batch of messages.
+//
+// Nothing in this tree calls compress yet: the message-set writer (Conn's
+// produce path, or a higher-level Writer) isn't present here, so there's
+// nowhere to plug it in without inventing that code from scratch.
+func compress(codec CompressionCodec, msgs []message) ([]message, error) {
+	if codec == nil || len(msgs) == 0 {
+		return msgs, nil
+	}
+
+	wrapped, err := wrapCompressed(codec, msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return []message{wrapped}, nil
+}
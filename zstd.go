@@ -0,0 +1,35 @@
+package kafka
+
+import (
+	"github.com/klauspost/compress/zstd"
+)
+
+func init() {
+	RegisterCompressionCodec(&zstdCodec{})
+}
+
+// zstdCodec implements the CompressionCodec interface and supports
+// compressing/decompressing message sets with zstd (KIP-110).
+type zstdCodec struct{}
+
+func (c *zstdCodec) Code() int8 {
+	return compressionZstd
+}
+
+func (c *zstdCodec) Encode(src []byte) ([]byte, error) {
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer enc.Close()
+	return enc.EncodeAll(src, nil), nil
+}
+
+func (c *zstdCodec) Decode(src []byte) ([]byte, error) {
+	dec, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer dec.Close()
+	return dec.DecodeAll(src, nil)
+}